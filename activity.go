@@ -0,0 +1,338 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Пороги умеренной интенсивности (около 3 METs) по средней скорости в км/ч.
+const (
+	ModerateRunningSpeed  = 6   // медленнее уже скорее быстрая ходьба
+	ModerateWalkingSpeed  = 4.8 // быстрая ходьба
+	ModerateSwimmingSpeed = 1   // спокойное плавание
+)
+
+// activeMinutes возвращает всю длительность, если скорость не ниже порога, иначе 0.
+// Нагрузка на тренировке постоянная, поэтому тренировка засчитывается целиком или никак.
+func activeMinutes(d time.Duration, speed, threshold float64) time.Duration {
+	if d <= 0 || speed < threshold {
+		return 0
+	}
+	return d
+}
+
+// ActiveMinutes возвращает время бега с умеренной или высокой интенсивностью.
+func (r Running) ActiveMinutes() time.Duration {
+	return activeMinutes(r.Duration, r.meanSpeed(), ModerateRunningSpeed)
+}
+
+// ActiveMinutes возвращает время ходьбы с умеренной или высокой интенсивностью.
+func (w Walking) ActiveMinutes() time.Duration {
+	return activeMinutes(w.Duration, w.meanSpeed(), ModerateWalkingSpeed)
+}
+
+// ActiveMinutes возвращает время плавания с умеренной или высокой интенсивностью.
+func (s Swimming) ActiveMinutes() time.Duration {
+	return activeMinutes(s.Duration, s.meanSpeed(), ModerateSwimmingSpeed)
+}
+
+// averageGradePct возвращает уклон в процентах при наборе высоты elevation м
+// на дистанции distance км: набор_высоты_в_м / (дистанция_в_км * м_в_км) * 100.
+// Без дистанции уклон не определен и возвращается 0.
+func averageGradePct(elevation, distance float64) float64 {
+	if distance <= 0 {
+		return 0
+	}
+	return elevation / (distance * MInKm) * 100
+}
+
+// AverageGradePct возвращает средний уклон тренировки в процентах.
+func (t Training) AverageGradePct() float64 {
+	return averageGradePct(t.ElevationGain, t.distance())
+}
+
+// AverageGradePct возвращает средний уклон ходьбы в процентах по дистанции
+// с учетом оценки длины шага.
+// Это переопределенный метод AverageGradePct() из Training.
+func (w Walking) AverageGradePct() float64 {
+	return averageGradePct(w.ElevationGain, w.distance())
+}
+
+// AverageGradePct возвращает средний уклон заплыва в процентах по дистанции в бассейне.
+// Это переопределенный метод AverageGradePct() из Training.
+func (s Swimming) AverageGradePct() float64 {
+	return averageGradePct(s.ElevationGain, s.distance())
+}
+
+// DurationForSteps возвращает, сколько времени от начала тренировки нужно,
+// чтобы сделать targetSteps шагов, если темп шагов на тренировке сохранится.
+// Шаги считаются с учетом ActionFloat. Без шагов или длительности темп
+// не определен и возвращается 0.
+func (t Training) DurationForSteps(targetSteps int) time.Duration {
+	steps := t.action()
+	if steps <= 0 || t.Duration <= 0 || targetSteps <= 0 {
+		return 0
+	}
+	return time.Duration(float64(targetSteps) / steps * float64(t.Duration))
+}
+
+// PaceVariability возвращает коэффициент вариации темпа по отрезкам тренировки:
+// стандартное_отклонение_темпа / средний_темп. Отрезки без дистанции или времени
+// пропускаются. Без разбивки на отрезки темп постоянный и возвращается 0.
+func (t Training) PaceVariability() float64 {
+	var paces []float64
+	for _, seg := range t.Segments {
+		if seg.Duration > 0 && seg.Distance > 0 {
+			paces = append(paces, seg.pace())
+		}
+	}
+	if len(paces) < 2 {
+		return 0
+	}
+
+	var sum float64
+	for _, p := range paces {
+		sum += p
+	}
+	mean := sum / float64(len(paces))
+
+	var squares float64
+	for _, p := range paces {
+		squares += (p - mean) * (p - mean)
+	}
+	return math.Sqrt(squares/float64(len(paces))) / mean
+}
+
+// TrimmedMeanSpeed возвращает среднюю скорость в км/ч по отрезкам тренировки без
+// самых медленных dropPct процентов отрезков, например остановок на светофорах.
+// Количество отбрасываемых отрезков округляется вниз, хотя бы один отрезок остается.
+// Скорость считается как суммарная дистанция оставшихся отрезков, деленная
+// на их суммарную длительность. Отрезки без времени пропускаются; без разбивки
+// на отрезки возвращается средняя скорость всей тренировки.
+func (t Training) TrimmedMeanSpeed(dropPct float64) float64 {
+	return trimmedMeanSpeed(t.Segments, dropPct, t.meanSpeed())
+}
+
+// TrimmedMeanSpeed возвращает среднюю скорость ходьбы по отрезкам без самых медленных
+// dropPct процентов, а без отрезков — среднюю скорость с учетом оценки длины шага.
+// Это переопределенный метод TrimmedMeanSpeed() из Training.
+func (w Walking) TrimmedMeanSpeed(dropPct float64) float64 {
+	return trimmedMeanSpeed(w.Segments, dropPct, w.meanSpeed())
+}
+
+// TrimmedMeanSpeed возвращает среднюю скорость плавания по отрезкам без самых медленных
+// dropPct процентов, а без отрезков — среднюю скорость по бассейну.
+// Это переопределенный метод TrimmedMeanSpeed() из Training.
+func (s Swimming) TrimmedMeanSpeed(dropPct float64) float64 {
+	return trimmedMeanSpeed(s.Segments, dropPct, s.meanSpeed())
+}
+
+// trimmedMeanSpeed возвращает среднюю скорость по отрезкам all без самых
+// медленных dropPct процентов или fallback, если отрезков со временем нет.
+func trimmedMeanSpeed(all []Segment, dropPct, fallback float64) float64 {
+	segments := make([]Segment, 0, len(all))
+	for _, seg := range all {
+		if seg.Duration > 0 {
+			segments = append(segments, seg)
+		}
+	}
+	if len(segments) == 0 {
+		return fallback
+	}
+
+	speed := func(seg Segment) float64 {
+		return seg.Distance / seg.Duration.Hours()
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return speed(segments[i]) < speed(segments[j])
+	})
+
+	dropPct = math.Max(0, math.Min(dropPct, 100))
+	drop := int(float64(len(segments)) * dropPct / 100)
+	if drop >= len(segments) {
+		drop = len(segments) - 1
+	}
+
+	var distance float64
+	var duration time.Duration
+	for _, seg := range segments[drop:] {
+		distance += seg.Distance
+		duration += seg.Duration
+	}
+	return distance / duration.Hours()
+}
+
+// TruncateTo возвращает копию тренировки, обрезанную до продолжительности max,
+// например чтобы сравнивать тренировки в одинаковом окне. Темп считается
+// равномерным: количество повторов уменьшается пропорционально времени
+// и округляется до целого, отрезки Segments и WeightProfile обрезаются по времени,
+// дистанция последнего оставшегося отрезка уменьшается пропорционально.
+// Если тренировка не длиннее max или max неположительно, возвращается копия без изменений.
+func (t Training) TruncateTo(max time.Duration) Training {
+	if max <= 0 || t.Duration <= max {
+		return t
+	}
+
+	ratio := float64(max) / float64(t.Duration)
+	t.Duration = max
+	t.Action = int(math.Round(float64(t.Action) * ratio))
+	t.ActionFloat *= ratio
+
+	var segments []Segment
+	var elapsed time.Duration
+	for _, seg := range t.Segments {
+		if elapsed >= max {
+			break
+		}
+		if rest := max - elapsed; seg.Duration > rest {
+			seg.Distance *= float64(rest) / float64(seg.Duration)
+			seg.Duration = rest
+		}
+		elapsed += seg.Duration
+		segments = append(segments, seg)
+	}
+	t.Segments = segments
+
+	var profile []WeightSegment
+	elapsed = 0
+	for _, seg := range t.WeightProfile {
+		if elapsed >= max {
+			break
+		}
+		if rest := max - elapsed; seg.Duration > rest {
+			seg.Duration = rest
+		}
+		elapsed += seg.Duration
+		profile = append(profile, seg)
+	}
+	t.WeightProfile = profile
+	return t
+}
+
+// StrokesForSWOLF возвращает количество гребков на длину бассейна, при котором
+// достигается целевой SWOLF, если длина проплывается за lengthTime.
+// SWOLF = гребки_на_длину + секунды_на_длину, поэтому гребки = SWOLF - секунды.
+// Для некорректных входных данных или недостижимой цели возвращается 0.
+func StrokesForSWOLF(targetSWOLF float64, lengthTime time.Duration) int {
+	if targetSWOLF <= 0 || lengthTime <= 0 {
+		return 0
+	}
+	strokes := math.Round(targetSWOLF - lengthTime.Seconds())
+	if strokes <= 0 {
+		return 0
+	}
+	return int(strokes)
+}
+
+// stepsPerCalorie возвращает количество шагов на килокалорию или 0 без калорий.
+func stepsPerCalorie(steps, calories float64) float64 {
+	if calories <= 0 {
+		return 0
+	}
+	return steps / calories
+}
+
+// StepsPerCalorie возвращает количество шагов бега на одну потраченную килокалорию.
+func (r Running) StepsPerCalorie() float64 {
+	return stepsPerCalorie(r.action(), r.Calories())
+}
+
+// StepsPerCalorie возвращает количество шагов ходьбы на одну потраченную килокалорию.
+func (w Walking) StepsPerCalorie() float64 {
+	return stepsPerCalorie(w.action(), w.Calories())
+}
+
+// StepCounter реализуют тренировки, в которых повторы — это шаги.
+type StepCounter interface {
+	Steps() int
+}
+
+// Steps возвращает количество шагов пробежки.
+func (r Running) Steps() int {
+	return int(math.Round(r.action()))
+}
+
+// Steps возвращает количество шагов прогулки.
+func (w Walking) Steps() int {
+	return int(math.Round(w.action()))
+}
+
+// TotalSteps возвращает сумму шагов по тренировкам, реализующим StepCounter.
+// Гребки при плавании и другие повторы, которые не являются шагами, не учитываются.
+func TotalSteps(trainings []CaloriesCalculator) int {
+	var total int
+	for _, t := range trainings {
+		if s, ok := t.(StepCounter); ok {
+			total += s.Steps()
+		}
+	}
+	return total
+}
+
+// perKg возвращает копию тренировки с весом пользователя 1 кг без отрезков веса.
+func (t Training) perKg() Training {
+	t.Weight = 1
+	t.WeightProfile = nil
+	return t
+}
+
+// weightForCalories возвращает вес, при котором тренировка с kcalPerKg килокалориями
+// на килограмм веса потратит targetKcal килокалорий, или 0, если это невозможно.
+func weightForCalories(targetKcal, kcalPerKg float64) float64 {
+	if targetKcal <= 0 || kcalPerKg <= 0 {
+		return 0
+	}
+	return targetKcal / kcalPerKg
+}
+
+// WeightForCalories возвращает вес пользователя в кг, при котором тренировка
+// с теми же остальными параметрами потратила бы targetKcal килокалорий.
+// Все формулы калорий линейны по весу, поэтому вес равен цели, деленной на калории
+// тренировки весом 1 кг. Результат не проверяется на диапазон допустимых весов;
+// при неположительной цели или некорректной тренировке возвращается 0.
+func (t Training) WeightForCalories(targetKcal float64) float64 {
+	return weightForCalories(targetKcal, t.perKg().Calories())
+}
+
+// WeightForCalories возвращает вес пользователя, при котором пробежка потратила бы
+// targetKcal килокалорий. Это переопределенный метод WeightForCalories() из Training.
+func (r Running) WeightForCalories(targetKcal float64) float64 {
+	r.Training = r.perKg()
+	return weightForCalories(targetKcal, r.Calories())
+}
+
+// WeightForCalories возвращает вес пользователя, при котором прогулка потратила бы
+// targetKcal килокалорий. Это переопределенный метод WeightForCalories() из Training.
+func (w Walking) WeightForCalories(targetKcal float64) float64 {
+	w.Training = w.perKg()
+	return weightForCalories(targetKcal, w.Calories())
+}
+
+// WeightForCalories возвращает вес пользователя, при котором заплыв потратил бы
+// targetKcal килокалорий. Это переопределенный метод WeightForCalories() из Training.
+func (s Swimming) WeightForCalories(targetKcal float64) float64 {
+	s.Training = s.perKg()
+	return weightForCalories(targetKcal, s.Calories())
+}
+
+// WeightForCalories возвращает вес пользователя, при котором поездка на велосипеде
+// потратила бы targetKcal килокалорий. Это переопределенный метод WeightForCalories() из Training.
+func (c Cycling) WeightForCalories(targetKcal float64) float64 {
+	c.Training = c.perKg()
+	return weightForCalories(targetKcal, c.Calories())
+}
+
+// WeightForCalories возвращает вес пользователя, при котором гребля потратила бы
+// targetKcal килокалорий. Это переопределенный метод WeightForCalories() из Training.
+func (r Rowing) WeightForCalories(targetKcal float64) float64 {
+	r.Training = r.perKg()
+	return weightForCalories(targetKcal, r.Calories())
+}
+
+// WeightForCalories для измеренной тренировки всегда возвращает 0: калории измерены
+// устройством, а не рассчитаны по весу, поэтому подобрать вес нельзя.
+// Это переопределенный метод WeightForCalories() из Training.
+func (r Recorded) WeightForCalories(targetKcal float64) float64 {
+	return 0
+}
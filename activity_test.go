@@ -0,0 +1,288 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// testWalk возвращает прогулку из steps шагов за время d, вес 70 кг, рост 175 см.
+func testWalk(steps int, d time.Duration) Walking {
+	return Walking{
+		Training: Training{
+			TrainingType: "Ходьба",
+			Action:       steps,
+			LenStep:      LenStep,
+			Duration:     d,
+			Weight:       70,
+		},
+		Height: 175,
+	}
+}
+
+func TestActiveMinutesWalking(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps int
+		want  time.Duration
+	}{
+		{"slow 3.9 km/h", 6000, 0},
+		{"brisk 6.5 km/h", 10000, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testWalk(tt.steps, time.Hour).ActiveMinutes(); got != tt.want {
+				t.Errorf("ActiveMinutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAverageGradePct(t *testing.T) {
+	run := testRun()
+	run.Action = 15385 // 10 км
+	run.ElevationGain = 500
+	if got := run.AverageGradePct(); !almostEqual(got, 5, 0.001) {
+		t.Errorf("AverageGradePct() = %v, want 5%% for 500 m over 10 km", got)
+	}
+
+	run.Action = 0
+	if got := run.AverageGradePct(); got != 0 {
+		t.Errorf("AverageGradePct() without distance = %v, want 0", got)
+	}
+}
+
+func TestAverageGradePctUsesTypeDistance(t *testing.T) {
+	// Шаг не задан: дистанция оценивается по росту.
+	walk := testWalk(10000, 2*time.Hour)
+	walk.LenStep = 0
+	walk.ElevationGain = 100
+	want := 100 / (walk.Distance() * MInKm) * 100
+	if got := walk.AverageGradePct(); walk.Distance() <= 0 || !almostEqual(got, want, 1e-9) {
+		t.Errorf("walking AverageGradePct() = %v, want %v", got, want)
+	}
+
+	// Дистанция плавания считается по бассейну, а не по гребкам.
+	swim := testSwim(40, 30*time.Minute) // 1 км
+	swim.Action = 10
+	swim.ElevationGain = 10
+	if got := swim.AverageGradePct(); !almostEqual(got, 1, 1e-9) {
+		t.Errorf("swimming AverageGradePct() = %v, want 1%%", got)
+	}
+}
+
+func TestDurationForSteps(t *testing.T) {
+	walk := testWalk(6000, time.Hour) // 100 шагов в минуту
+	if got, want := walk.DurationForSteps(10000), 100*time.Minute; got != want {
+		t.Errorf("DurationForSteps(10000) = %v, want %v", got, want)
+	}
+	if got := testWalk(0, time.Hour).DurationForSteps(10000); got != 0 {
+		t.Errorf("DurationForSteps() without steps = %v, want 0", got)
+	}
+	if got := testWalk(6000, 0).DurationForSteps(10000); got != 0 {
+		t.Errorf("DurationForSteps() without duration = %v, want 0", got)
+	}
+}
+
+func TestPaceVariability(t *testing.T) {
+	run := testRun()
+	if got := run.PaceVariability(); got != 0 {
+		t.Errorf("PaceVariability() without segments = %v, want 0", got)
+	}
+
+	run.Segments = []Segment{
+		{Duration: 5 * time.Minute, Distance: 1},
+		{Duration: 7 * time.Minute, Distance: 1},
+		{Duration: 3 * time.Minute}, // без дистанции
+	}
+	// Темп 5 и 7 мин/км: среднее 6, стандартное отклонение 1.
+	if got := run.PaceVariability(); !almostEqual(got, 1.0/6, 1e-9) {
+		t.Errorf("PaceVariability() = %v, want %v", got, 1.0/6)
+	}
+
+	run.Segments = []Segment{
+		{Duration: 6 * time.Minute, Distance: 1},
+		{Duration: 12 * time.Minute, Distance: 2},
+	}
+	if got := run.PaceVariability(); !almostEqual(got, 0, 1e-12) {
+		t.Errorf("PaceVariability() for even segments = %v, want 0", got)
+	}
+}
+
+func TestStrokesForSWOLF(t *testing.T) {
+	tests := []struct {
+		name   string
+		swolf  float64
+		length time.Duration
+		want   int
+	}{
+		{"reachable", 40, 22 * time.Second, 18},
+		{"rounded", 40.4, 22 * time.Second, 18},
+		{"too slow", 40, 45 * time.Second, 0},
+		{"zero SWOLF", 0, 22 * time.Second, 0},
+		{"zero time", 40, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StrokesForSWOLF(tt.swolf, tt.length); got != tt.want {
+				t.Errorf("StrokesForSWOLF(%v, %v) = %d, want %d", tt.swolf, tt.length, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepsPerCalorieWalk(t *testing.T) {
+	walk := testWalk(6000, time.Hour)
+	calories := walk.Calories()
+	if calories <= 0 {
+		t.Fatalf("Calories() = %v, want > 0", calories)
+	}
+	if got, want := walk.StepsPerCalorie(), 6000/calories; !almostEqual(got, want, 1e-9) {
+		t.Errorf("StepsPerCalorie() = %v, want %v", got, want)
+	}
+
+	walk.Weight = 0
+	if got := walk.StepsPerCalorie(); got != 0 {
+		t.Errorf("StepsPerCalorie() without calories = %v, want 0", got)
+	}
+}
+
+func TestWeightForCaloriesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		c    interface {
+			CaloriesCalculator
+			WeightForCalories(targetKcal float64) float64
+		}
+		withWeight func(w float64) CaloriesCalculator
+	}{
+		{"running", testRun(), func(w float64) CaloriesCalculator { r := testRun(); r.Weight = w; return r }},
+		{"walking", testWalk(6000, time.Hour), func(w float64) CaloriesCalculator { x := testWalk(6000, time.Hour); x.Weight = w; return x }},
+		{"swimming", testSwim(40, 30*time.Minute), func(w float64) CaloriesCalculator { s := testSwim(40, 30*time.Minute); s.Weight = w; return s }},
+		{"cycling", testRide(5000, time.Hour), func(w float64) CaloriesCalculator { c := testRide(5000, time.Hour); c.Weight = w; return c }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const target = 400
+			weight := tt.c.WeightForCalories(target)
+			if weight <= 0 {
+				t.Fatalf("WeightForCalories(%d) = %v, want > 0", target, weight)
+			}
+			if got := tt.withWeight(weight).Calories(); !almostEqual(got, target, 1e-6) {
+				t.Errorf("Calories() at weight %v = %v, want %d", weight, got, target)
+			}
+			if got := tt.c.WeightForCalories(0); got != 0 {
+				t.Errorf("WeightForCalories(0) = %v, want 0", got)
+			}
+		})
+	}
+
+	broken := testRun()
+	broken.Duration = 0
+	if got := broken.WeightForCalories(400); got != 0 {
+		t.Errorf("WeightForCalories() without duration = %v, want 0", got)
+	}
+
+	recorded := Recorded{Training: Training{TrainingType: "Бег", Duration: time.Hour, Weight: 70}, RecordedCalories: 600}
+	if got := recorded.WeightForCalories(600); got != 0 {
+		t.Errorf("Recorded.WeightForCalories() = %v, want 0 for a measured session", got)
+	}
+}
+
+func TestActionFloat(t *testing.T) {
+	whole := testRun() // 5000 шагов
+	fractional := testRun()
+	fractional.ActionFloat = 5000.8
+
+	if got, want := fractional.Distance(), whole.Distance()*5000.8/5000; !almostEqual(got, want, 1e-12) || got <= whole.Distance() {
+		t.Errorf("Distance() with ActionFloat = %v, want %v, longer than %v", got, want, whole.Distance())
+	}
+
+	walk := testWalk(6000, time.Hour)
+	walk.ActionFloat = 6000.5
+	if got, want := walk.StepsPerCalorie(), 6000.5/walk.Calories(); !almostEqual(got, want, 1e-12) {
+		t.Errorf("StepsPerCalorie() with ActionFloat = %v, want %v", got, want)
+	}
+
+	walk = testWalk(0, time.Hour)
+	walk.ActionFloat = 5000
+	if got, want := walk.DurationForSteps(10000), 2*time.Hour; got != want {
+		t.Errorf("DurationForSteps() with ActionFloat only = %v, want %v", got, want)
+	}
+}
+
+func TestTrimmedMeanSpeed(t *testing.T) {
+	run := testRun()
+	run.Segments = []Segment{
+		{Duration: 5 * time.Minute, Distance: 1},
+		{Duration: 5 * time.Minute, Distance: 1},
+		{Duration: 10 * time.Minute, Distance: 0.1}, // светофор
+		{Duration: 6 * time.Minute, Distance: 1},
+	}
+	// Без самого медленного отрезка: 3 км за 16 минут.
+	if got, want := run.TrimmedMeanSpeed(25), 3/(16.0/60); !almostEqual(got, want, 1e-9) {
+		t.Errorf("TrimmedMeanSpeed(25) = %v, want %v", got, want)
+	}
+	if got, want := run.TrimmedMeanSpeed(0), 3.1/(26.0/60); !almostEqual(got, want, 1e-9) {
+		t.Errorf("TrimmedMeanSpeed(0) = %v, want %v", got, want)
+	}
+	if got, want := run.TrimmedMeanSpeed(100), 1/(5.0/60); !almostEqual(got, want, 1e-9) {
+		t.Errorf("TrimmedMeanSpeed(100) = %v, want the fastest segment %v", got, want)
+	}
+}
+
+func TestTrimmedMeanSpeedFallbackUsesTypeSpeed(t *testing.T) {
+	swim := testSwim(40, 30*time.Minute)
+	swim.Action = 10
+	if got := swim.TrimmedMeanSpeed(10); got != swim.MeanSpeed() || got != 2 {
+		t.Errorf("swimming TrimmedMeanSpeed() = %v, want pool-based %v", got, swim.MeanSpeed())
+	}
+
+	walk := testWalk(10000, 2*time.Hour)
+	walk.LenStep = 0
+	if got := walk.TrimmedMeanSpeed(10); got != walk.MeanSpeed() || got <= 0 {
+		t.Errorf("walking TrimmedMeanSpeed() = %v, want %v from the estimated stride", got, walk.MeanSpeed())
+	}
+
+	run := testRun()
+	if got := run.TrimmedMeanSpeed(10); got != run.MeanSpeed() {
+		t.Errorf("running TrimmedMeanSpeed() = %v, want %v", got, run.MeanSpeed())
+	}
+}
+
+func TestTotalSteps(t *testing.T) {
+	trainings := []CaloriesCalculator{
+		testWalk(4000, 40*time.Minute),
+		testRun(), // 5000 шагов
+		testSwim(40, 30*time.Minute),
+		testRide(3636, 40*time.Minute),
+	}
+	if got := TotalSteps(trainings); got != 9000 {
+		t.Errorf("TotalSteps() = %d, want 9000 (swims and rides excluded)", got)
+	}
+	if got := TotalSteps(nil); got != 0 {
+		t.Errorf("TotalSteps(nil) = %d, want 0", got)
+	}
+	if _, ok := CaloriesCalculator(testSwim(40, 30*time.Minute)).(StepCounter); ok {
+		t.Error("Swimming must not implement StepCounter")
+	}
+}
+
+func TestTruncateTo(t *testing.T) {
+	run := testRun()
+	run.Action = 6000
+	run.Duration = 45 * time.Minute
+
+	got := run.TruncateTo(30 * time.Minute)
+	if got.Duration != 30*time.Minute {
+		t.Errorf("Duration = %v, want 30m", got.Duration)
+	}
+	if got.Action != 4000 {
+		t.Errorf("Action = %d, want 4000", got.Action)
+	}
+	if run.Action != 6000 || run.Duration != 45*time.Minute {
+		t.Error("TruncateTo modified the original training")
+	}
+	if same := run.TruncateTo(time.Hour); same.Action != 6000 || same.Duration != 45*time.Minute {
+		t.Errorf("TruncateTo(1h) = %d in %v, want unchanged", same.Action, same.Duration)
+	}
+}
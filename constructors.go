@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultPoolLength длина бассейна по умолчанию в м.
+const DefaultPoolLength = 25
+
+// NewSwimFromDistance возвращает заплыв на distanceKm км за время d.
+// Заплыв считается в бассейне длиной DefaultPoolLength, количество пересечений
+// округляется до целого, поэтому дистанция точна до длины бассейна.
+// Количество гребков оценивается по длине гребка SwimmingLenStep.
+func NewSwimFromDistance(distanceKm float64, d time.Duration, weight float64) Swimming {
+	meters := math.Max(distanceKm, 0) * MInKm
+	return Swimming{
+		Training: Training{
+			TrainingType: string(KindSwimming),
+			Action:       int(math.Round(meters / SwimmingLenStep)),
+			LenStep:      SwimmingLenStep,
+			Duration:     d,
+			Weight:       weight,
+		},
+		LengthPool: DefaultPoolLength,
+		CountPool:  int(math.Round(meters / DefaultPoolLength)),
+	}
+}
+
+// NewRunFromPace возвращает пробежку с темпом paceMinPerKm мин/км длительностью d.
+// Дистанция равна длительности в минутах, деленной на темп, количество шагов
+// оценивается по длине шага LenStep. При неположительном темпе дистанция нулевая.
+func NewRunFromPace(paceMinPerKm float64, d time.Duration, weight float64) Running {
+	var km float64
+	if paceMinPerKm > 0 {
+		km = d.Minutes() / paceMinPerKm
+	}
+	return Running{
+		Training: Training{
+			TrainingType: string(KindRunning),
+			Action:       int(math.Round(km * MInKm / LenStep)),
+			LenStep:      LenStep,
+			Duration:     d,
+			Weight:       weight,
+		},
+	}
+}
+
+// NewRunning возвращает пробежку из action шагов за время duration
+// или ошибку, если вес или продолжительность некорректны.
+func NewRunning(action int, duration time.Duration, weight float64) (Running, error) {
+	r := Running{
+		Training: Training{
+			TrainingType: string(KindRunning),
+			Action:       action,
+			LenStep:      LenStep,
+			Duration:     duration,
+			Weight:       weight,
+		},
+	}
+	if err := r.validate(); err != nil {
+		return Running{}, fmt.Errorf("бег: %w", err)
+	}
+	return r, nil
+}
+
+// NewWalking возвращает прогулку из action шагов за время duration пользователя
+// ростом height см или ошибку, если вес, продолжительность или рост некорректны.
+func NewWalking(action int, duration time.Duration, weight, height float64) (Walking, error) {
+	w := Walking{
+		Training: Training{
+			TrainingType: string(KindWalking),
+			Action:       action,
+			LenStep:      LenStep,
+			Duration:     duration,
+			Weight:       weight,
+		},
+		Height: height,
+	}
+	if err := w.validate(); err != nil {
+		return Walking{}, fmt.Errorf("ходьба: %w", err)
+	}
+	return w, nil
+}
+
+// NewSwimming возвращает заплыв из action гребков за время duration, countPool раз
+// пересекая бассейн длиной lengthPool м, или ошибку, если вес, продолжительность
+// или размеры бассейна некорректны.
+func NewSwimming(action int, duration time.Duration, weight float64, lengthPool, countPool int) (Swimming, error) {
+	s := Swimming{
+		Training: Training{
+			TrainingType: string(KindSwimming),
+			Action:       action,
+			LenStep:      SwimmingLenStep,
+			Duration:     duration,
+			Weight:       weight,
+		},
+		LengthPool: lengthPool,
+		CountPool:  countPool,
+	}
+	if err := s.validate(); err != nil {
+		return Swimming{}, fmt.Errorf("плавание: %w", err)
+	}
+	return s, nil
+}
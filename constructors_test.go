@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSwimFromDistance(t *testing.T) {
+	s := NewSwimFromDistance(1.5, 45*time.Minute, 70)
+	if s.CountPool != 60 || s.LengthPool != DefaultPoolLength {
+		t.Errorf("pool = %d x %d m, want 60 x %d m", s.CountPool, s.LengthPool, DefaultPoolLength)
+	}
+	info := s.TrainingInfo()
+	if !almostEqual(info.Distance, 1.5, 1e-9) {
+		t.Errorf("Distance = %v, want 1.5", info.Distance)
+	}
+	if want := 1.5 / 0.75; !almostEqual(info.Speed, want, 1e-9) {
+		t.Errorf("Speed = %v, want %v", info.Speed, want)
+	}
+}
+
+func TestNewRunFromPace(t *testing.T) {
+	r := NewRunFromPace(5, 30*time.Minute, 70)
+	if r.Action != 9231 {
+		t.Errorf("Action = %d, want 9231 steps", r.Action)
+	}
+	if info := r.TrainingInfo(); !almostEqual(info.Distance, 6, 0.001) {
+		t.Errorf("Distance = %v, want 6 km", info.Distance)
+	}
+
+	for _, pace := range []float64{0, -5} {
+		if d := NewRunFromPace(pace, 30*time.Minute, 70).TrainingInfo().Distance; d != 0 {
+			t.Errorf("NewRunFromPace(%v) distance = %v, want 0", pace, d)
+		}
+	}
+}
+
+func TestConstructorsAcceptValid(t *testing.T) {
+	if r, err := NewRunning(5000, 30*time.Minute, 85); err != nil || r.Action != 5000 || r.LenStep != LenStep {
+		t.Errorf("NewRunning() = %+v, %v, want a run", r, err)
+	}
+	if w, err := NewWalking(6000, time.Hour, 70, 175); err != nil || w.Height != 175 {
+		t.Errorf("NewWalking() = %+v, %v, want a walk", w, err)
+	}
+	if s, err := NewSwimming(720, 30*time.Minute, 70, 25, 40); err != nil || s.Distance() != 1 {
+		t.Errorf("NewSwimming() = %+v, %v, want a 1 km swim", s, err)
+	}
+}
+
+func TestConstructorsRejectInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    error
+		message string
+	}{
+		{"running zero weight", second(NewRunning(5000, 30*time.Minute, 0)), ErrInvalidWeight, "бег"},
+		{"running zero duration", second(NewRunning(5000, 0, 85)), ErrZeroDuration, "бег"},
+		{"walking zero height", second(NewWalking(6000, time.Hour, 70, 0)), ErrInvalidHeight, "ходьба"},
+		{"walking negative weight", second(NewWalking(6000, time.Hour, -70, 175)), ErrInvalidWeight, "ходьба"},
+		{"swimming zero pool", second(NewSwimming(720, 30*time.Minute, 70, 0, 40)), ErrInvalidPool, "плавание"},
+		{"swimming negative count", second(NewSwimming(720, 30*time.Minute, 70, 25, -1)), ErrInvalidPool, "плавание"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Fatalf("error = %v, want %v", tt.err, tt.want)
+			}
+			if !strings.HasPrefix(tt.err.Error(), tt.message+": ") {
+				t.Errorf("error %q does not name the training type %q", tt.err, tt.message)
+			}
+		})
+	}
+}
+
+// second возвращает ошибку из результата конструктора.
+func second[T any](_ T, err error) error {
+	return err
+}
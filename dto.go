@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnknownTrainingType возвращается для тренировки неизвестного типа.
+var ErrUnknownTrainingType = errors.New("неизвестный тип тренировки")
+
+// Duration продолжительность тренировки в JSON.
+// Принимает как число наносекунд, так и строку вида "Ч:ММ:СС", например "1:30:00".
+type Duration time.Duration
+
+// UnmarshalJSON разбирает продолжительность из числа наносекунд или строки "Ч:ММ:СС".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var ns int64
+		if err := json.Unmarshal(data, &ns); err != nil {
+			return fmt.Errorf("продолжительность %s: ожидается число наносекунд или строка Ч:ММ:СС", data)
+		}
+		*d = Duration(ns)
+		return nil
+	}
+
+	parsed, err := parseClock(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// parseClock разбирает продолжительность в формате "Ч:ММ:СС".
+func parseClock(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("продолжительность %q: ожидается формат Ч:ММ:СС", s)
+	}
+
+	var values [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || (i > 0 && v >= 60) {
+			return 0, fmt.Errorf("продолжительность %q: ожидается формат Ч:ММ:СС", s)
+		}
+		values[i] = v
+	}
+	return time.Duration(values[0])*time.Hour +
+		time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second, nil
+}
+
+// TrainingDTO описывает тренировку в JSON.
+// Поля, специфичные для типа тренировки, заполняются только для этого типа.
+type TrainingDTO struct {
+	TrainingType string   `json:"training_type"`
+	Action       int      `json:"action"`
+	LenStep      float64  `json:"len_step"`
+	Duration     Duration `json:"duration"`
+	Weight       float64  `json:"weight"`
+	Height       float64  `json:"height,omitempty"`      // рост, только для ходьбы
+	LengthPool   int      `json:"length_pool,omitempty"` // длина бассейна, только для плавания
+	CountPool    int      `json:"count_pool,omitempty"`  // пересечения бассейна, только для плавания
+}
+
+// Calculator возвращает тренировку, описанную в DTO, по ее типу.
+func (d TrainingDTO) Calculator() (CaloriesCalculator, error) {
+	t := Training{
+		TrainingType: d.TrainingType,
+		Action:       d.Action,
+		LenStep:      d.LenStep,
+		Duration:     time.Duration(d.Duration),
+		Weight:       d.Weight,
+	}
+
+	switch TrainingKind(d.TrainingType) {
+	case KindRunning:
+		return Running{Training: t}, nil
+	case KindWalking:
+		return Walking{Training: t, Height: d.Height}, nil
+	case KindSwimming:
+		return Swimming{Training: t, LengthPool: d.LengthPool, CountPool: d.CountPool}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownTrainingType, d.TrainingType)
+}
+
+// infoMessageJSON представление InfoMessage в JSON.
+type infoMessageJSON struct {
+	TrainingType string      `json:"training_type"`
+	Duration     json.Number `json:"duration"` // в минутах
+	Distance     json.Number `json:"distance"`
+	Speed        json.Number `json:"speed"`
+	Calories     json.Number `json:"calories"`
+	Laps         int         `json:"laps,omitempty"`
+}
+
+// jsonNumber форматирует число для JSON с двумя знаками после точки.
+func jsonNumber(v float64) json.Number {
+	return json.Number(strconv.FormatFloat(v, 'f', 2, 64))
+}
+
+// MarshalJSON возвращает информацию о тренировке в JSON.
+// Длительность записывается в минутах, все числа — с двумя знаками после точки,
+// поэтому при обратном разборе значения совпадают с точностью до сотых.
+func (i InfoMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(infoMessageJSON{
+		TrainingType: i.TrainingType,
+		Duration:     jsonNumber(i.Duration.Minutes()),
+		Distance:     jsonNumber(i.Distance),
+		Speed:        jsonNumber(i.Speed),
+		Calories:     jsonNumber(i.Calories),
+		Laps:         i.Laps,
+	})
+}
+
+// ExampleJSON возвращает информацию о тренировке в JSON с отступами в два пробела,
+// например для примеров в документации API. Поля и числа те же, что у MarshalJSON.
+func (i InfoMessage) ExampleJSON() []byte {
+	// MarshalJSON не возвращает ошибок для InfoMessage
+	data, _ := json.MarshalIndent(i, "", "  ")
+	return data
+}
+
+// UnmarshalJSON разбирает информацию о тренировке из JSON, созданного MarshalJSON.
+func (i *InfoMessage) UnmarshalJSON(data []byte) error {
+	var v infoMessageJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var numbers [4]float64
+	for n, field := range []json.Number{v.Duration, v.Distance, v.Speed, v.Calories} {
+		if field == "" {
+			continue
+		}
+		f, err := field.Float64()
+		if err != nil {
+			return fmt.Errorf("информация о тренировке: %w", err)
+		}
+		numbers[n] = f
+	}
+
+	*i = InfoMessage{
+		TrainingType: v.TrainingType,
+		Duration:     time.Duration(math.Round(numbers[0] * float64(time.Minute))),
+		Distance:     numbers[1],
+		Speed:        numbers[2],
+		Calories:     numbers[3],
+		Laps:         v.Laps,
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want time.Duration
+	}{
+		{"nanoseconds", `5400000000000`, 90 * time.Minute},
+		{"clock", `"1:30:00"`, 90 * time.Minute},
+		{"clock with seconds", `"0:05:30"`, 5*time.Minute + 30*time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			if err := json.Unmarshal([]byte(tt.data), &d); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.data, err)
+			}
+			if time.Duration(d) != tt.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.data, time.Duration(d), tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationUnmarshalJSONInvalid(t *testing.T) {
+	for _, data := range []string{`"90 минут"`, `"1:75:00"`, `"1:30"`, `true`} {
+		var d Duration
+		if err := json.Unmarshal([]byte(data), &d); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want error", data, time.Duration(d))
+		}
+	}
+}
+
+func TestTrainingDTOCalculator(t *testing.T) {
+	data := `{"training_type": "Ходьба", "action": 20000, "len_step": 0.65, "duration": "3:45:00", "weight": 85, "height": 185}`
+	var dto TrainingDTO
+	if err := json.Unmarshal([]byte(data), &dto); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	c, err := dto.Calculator()
+	if err != nil {
+		t.Fatalf("Calculator() error = %v", err)
+	}
+	w, ok := c.(Walking)
+	if !ok {
+		t.Fatalf("Calculator() = %T, want Walking", c)
+	}
+	if w.Duration != 3*time.Hour+45*time.Minute || w.Height != 185 {
+		t.Errorf("Walking = %+v, want 3h45m and height 185", w)
+	}
+}
+
+func TestInfoMessageJSONRoundTrip(t *testing.T) {
+	info := InfoMessage{
+		TrainingType: "Бег",
+		Duration:     45 * time.Minute,
+		Distance:     7.5,
+		Speed:        10,
+		Calories:     512.25,
+		Laps:         3,
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"training_type":"Бег","duration":45.00,"distance":7.50,"speed":10.00,"calories":512.25,"laps":3}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var got InfoMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got != info {
+		t.Errorf("round trip = %+v, want %+v", got, info)
+	}
+}
+
+func TestExampleJSON(t *testing.T) {
+	info := testRun().TrainingInfo()
+	example := info.ExampleJSON()
+	if !json.Valid(example) {
+		t.Fatalf("ExampleJSON() is not valid JSON:\n%s", example)
+	}
+
+	marshaled, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, example); err != nil {
+		t.Fatalf("json.Compact() error = %v", err)
+	}
+	if compact.String() != string(marshaled) {
+		t.Errorf("ExampleJSON() compacted = %s, want %s", compact.String(), marshaled)
+	}
+
+	want := "{\n  \"training_type\": \"Бег\",\n  \"duration\": 30.00,\n  \"distance\": 3.25,\n  \"speed\": 6.50,\n  \"calories\": 302.91\n}"
+	if string(example) != want {
+		t.Errorf("ExampleJSON() =\n%s\nwant\n%s", example, want)
+	}
+}
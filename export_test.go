@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRun возвращает пробежку 5000 шагов за 30 минут весом 85 кг:
+// 3.25 км, 6.5 км/ч, 302.9145 ккал.
+func testRun() Running {
+	return Running{
+		Training: Training{
+			TrainingType: "Бег",
+			Action:       5000,
+			LenStep:      LenStep,
+			Duration:     30 * time.Minute,
+			Weight:       85,
+		},
+	}
+}
+
+func TestExportTCX(t *testing.T) {
+	run := testRun()
+	run.StartTime = time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := ExportTCX(&buf, run); err != nil {
+		t.Fatalf("ExportTCX() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("document does not start with the XML header:\n%s", buf.String())
+	}
+
+	var doc tcxDatabase
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if doc.XMLName.Space != tcxNamespace {
+		t.Errorf("namespace = %q, want %q", doc.XMLName.Space, tcxNamespace)
+	}
+	if len(doc.Activities) != 1 {
+		t.Fatalf("len(Activities) = %d, want 1", len(doc.Activities))
+	}
+
+	a := doc.Activities[0]
+	if a.Sport != "Running" {
+		t.Errorf("Sport = %q, want Running", a.Sport)
+	}
+	if a.ID != "2024-03-01T08:00:00Z" || a.Lap.StartTime != a.ID {
+		t.Errorf("Id = %q, StartTime = %q, want 2024-03-01T08:00:00Z", a.ID, a.Lap.StartTime)
+	}
+	if a.Lap.TotalTimeSeconds != 1800 {
+		t.Errorf("TotalTimeSeconds = %v, want 1800", a.Lap.TotalTimeSeconds)
+	}
+	if a.Lap.DistanceMeters != 3250 {
+		t.Errorf("DistanceMeters = %v, want 3250", a.Lap.DistanceMeters)
+	}
+	if a.Lap.Calories != 303 {
+		t.Errorf("Calories = %d, want 303", a.Lap.Calories)
+	}
+}
+
+func TestExportTCXSport(t *testing.T) {
+	tests := []struct {
+		name string
+		c    CaloriesCalculator
+		want string
+	}{
+		{"running", testRun(), "Running"},
+		{"cycling", testRide(5000, time.Hour), "Biking"},
+		{"swimming", testSwim(40, 30*time.Minute), "Other"},
+		{"brick", testBrick(), "Other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ExportTCX(&buf, tt.c); err != nil {
+				t.Fatalf("ExportTCX() error = %v", err)
+			}
+			if want := `Sport="` + tt.want + `"`; !strings.Contains(buf.String(), want) {
+				t.Errorf("document does not contain %s:\n%s", want, buf.String())
+			}
+		})
+	}
+}
+
+func TestCompactCodeRoundTrip(t *testing.T) {
+	info := testRun().TrainingInfo()
+	info.Laps = 8
+
+	code := info.CompactCode()
+	if strings.ContainsAny(code, "+/=") {
+		t.Errorf("CompactCode() = %q, want URL-safe base64 without padding", code)
+	}
+	got, err := ParseCompactCode(code)
+	if err != nil {
+		t.Fatalf("ParseCompactCode() error = %v", err)
+	}
+	if got != info {
+		t.Errorf("ParseCompactCode(CompactCode()) = %+v, want %+v", got, info)
+	}
+}
+
+func TestParseCompactCodeInvalid(t *testing.T) {
+	for _, code := range []string{"не base64", "AAAA"} {
+		if _, err := ParseCompactCode(code); !errors.Is(err, ErrInvalidCompactCode) {
+			t.Errorf("ParseCompactCode(%q) error = %v, want ErrInvalidCompactCode", code, err)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, []CaloriesCalculator{testRun()}); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "training_type,duration_min,distance_km,speed_kmh,calories\n" +
+		"Бег,30.00,3.25,6.50,302.91\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Units система единиц измерения при выводе тренировки.
+type Units int
+
+// Системы единиц измерения.
+const (
+	Metric   Units = iota // километры и км/ч
+	Imperial              // мили и мили в час
+)
+
+// MiInKm количество миль в одном километре.
+const MiInKm = 0.621371
+
+// StringIn возвращает строку с информацией о проведенной тренировке в системе единиц units.
+// В имперской системе дистанция выводится в милях, скорость — в милях в час,
+// килокалории не меняются.
+func (i InfoMessage) StringIn(units Units) string {
+	opts := DefaultFormatOptions()
+	opts.Units = units
+	return i.Format(opts)
+}
+
+// FormatOptions настройки вывода информации о тренировке.
+type FormatOptions struct {
+	Units            Units  // система единиц
+	DistanceDecimals int    // знаков после точки в дистанции
+	SpeedDecimals    int    // знаков после точки в скорости
+	CaloriesDecimals int    // знаков после точки в килокалориях
+	HumanDuration    bool   // длительность в виде "1ч 30м" вместо минут
+	ShowSeconds      bool   // секунды в длительности вида "1ч 30м 15с"
+	DecimalSep       string // разделитель дробной части; пустой — точка
+	DistanceUnit     string // подпись единицы дистанции; пустая — по Units
+	SpeedUnit        string // подпись единицы скорости; пустая — по Units
+}
+
+// DefaultFormatOptions возвращает настройки вывода, которые использует InfoMessage.String().
+func DefaultFormatOptions() FormatOptions {
+	return FormatOptions{
+		DistanceDecimals: 2,
+		SpeedDecimals:    2,
+		CaloriesDecimals: 2,
+	}
+}
+
+// Format возвращает строку с информацией о проведенной тренировке по настройкам opts.
+// Числа выводятся с нужным количеством знаков так же, как "%.*f", поэтому
+// с DefaultFormatOptions() вывод совпадает с прежним шаблоном "%.2f".
+// Без знаков после точки половина округляется от нуля. Подписи единиц
+// DistanceUnit и SpeedUnit меняют только текст, значения пересчитываются по Units.
+func (i InfoMessage) Format(opts FormatOptions) string {
+	distance, speed := i.Distance, i.Speed
+	distanceUnit, speedUnit := "км.", "км/ч"
+	if opts.Units == Imperial {
+		distance, speed = distance*MiInKm, speed*MiInKm
+		distanceUnit, speedUnit = "ми.", "ми/ч"
+	}
+	if opts.DistanceUnit != "" {
+		distanceUnit = opts.DistanceUnit
+	}
+	if opts.SpeedUnit != "" {
+		speedUnit = opts.SpeedUnit
+	}
+
+	// number заменяет точку в числе на разделитель opts.DecimalSep.
+	number := func(v string) string {
+		if opts.DecimalSep == "" {
+			return v
+		}
+		return strings.Replace(v, ".", opts.DecimalSep, 1)
+	}
+
+	duration := number(fmt.Sprintf("%v", i.Duration.Minutes())) + " мин"
+	if opts.HumanDuration {
+		duration = formatHumanDuration(i.Duration, opts.ShowSeconds)
+	}
+
+	return fmt.Sprintf("Тип тренировки: %s\nДлительность: %s\nДистанция: %s %s\nСр. скорость: %s %s\nПотрачено ккал: %s\n",
+		i.TrainingType,
+		duration,
+		number(formatDecimal(distance, opts.DistanceDecimals)),
+		distanceUnit,
+		number(formatDecimal(speed, opts.SpeedDecimals)),
+		speedUnit,
+		number(formatDecimal(i.Calories, opts.CaloriesDecimals)),
+	)
+}
+
+// FormatConfig настройки разделителя дробной части и единиц измерения в выводе.
+// Пустые поля заменяются значениями по умолчанию из DefaultFormatConfig().
+type FormatConfig struct {
+	DecimalSep   string // разделитель дробной части
+	DistanceUnit string // единица дистанции
+	SpeedUnit    string // единица скорости
+}
+
+// DefaultFormatConfig возвращает настройки, с которыми FormatWith выводит то же, что String().
+func DefaultFormatConfig() FormatConfig {
+	return FormatConfig{
+		DecimalSep:   ".",
+		DistanceUnit: "км.",
+		SpeedUnit:    "км/ч",
+	}
+}
+
+// FormatWith возвращает строку с информацией о проведенной тренировке, как String(),
+// но с разделителем дробной части и единицами измерения из cfg. Единицы меняются
+// только в подписях, значения выводятся в километрах и км/ч.
+// Это то же самое, что Format(DefaultFormatOptions()) с полями cfg.
+func (i InfoMessage) FormatWith(cfg FormatConfig) string {
+	opts := DefaultFormatOptions()
+	opts.DecimalSep = cfg.DecimalSep
+	opts.DistanceUnit = cfg.DistanceUnit
+	opts.SpeedUnit = cfg.SpeedUnit
+	return i.Format(opts)
+}
+
+// formatDecimal форматирует v с decimals знаками после точки так же, как "%.*f".
+// Без знаков после точки половина округляется от нуля: 702.5 выводится как 703.
+func formatDecimal(v float64, decimals int) string {
+	if decimals <= 0 {
+		return strconv.FormatFloat(math.Round(v), 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// formatHumanDuration форматирует продолжительность как "1ч 30м" или "1ч 30м 15с".
+// Часы опускаются, если их нет.
+func formatHumanDuration(d time.Duration, showSeconds bool) string {
+	if showSeconds {
+		d = d.Round(time.Second)
+	} else {
+		d = d.Round(time.Minute)
+	}
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+
+	var parts []string
+	if h > 0 {
+		parts = append(parts, fmt.Sprintf("%dч", h))
+	}
+	parts = append(parts, fmt.Sprintf("%dм", m))
+	if showSeconds {
+		parts = append(parts, fmt.Sprintf("%dс", s))
+	}
+	return strings.Join(parts, " ")
+}
+
+// InfoFormatter форматирует информацию о тренировке, например в CSV, JSON или Markdown.
+type InfoFormatter interface {
+	Format(i InfoMessage) string
+}
+
+// DefaultFormatter форматирует информацию о тренировке так же, как InfoMessage.String().
+type DefaultFormatter struct{}
+
+// Format возвращает строку с информацией о проведенной тренировке.
+func (DefaultFormatter) Format(i InfoMessage) string {
+	return i.String()
+}
+
+// ExtendedFormatter форматирует информацию о тренировке как InfoMessage.String()
+// и добавляет килокалории в минуту и на километр.
+type ExtendedFormatter struct{}
+
+// Format возвращает строку с информацией о проведенной тренировке и ее интенсивностью.
+func (ExtendedFormatter) Format(i InfoMessage) string {
+	return fmt.Sprintf("%sКкал в минуту: %s\nКкал на км: %s\n",
+		i.String(),
+		formatDecimal(i.CaloriesPerMinute(), 2),
+		formatDecimal(i.CaloriesPerKm(), 2),
+	)
+}
+
+// BarChart возвращает горизонтальную диаграмму по тренировкам для вывода в терминал.
+// Каждой тренировке соответствует строка с ее типом, полосой из символов '#'
+// и значением metric. Самая длинная полоса имеет длину width, остальные
+// масштабируются относительно наибольшего значения.
+func BarChart(trainings []CaloriesCalculator, metric func(InfoMessage) float64, width int) string {
+	infos := make([]InfoMessage, len(trainings))
+	values := make([]float64, len(trainings))
+	var maxValue float64
+	var labelWidth int
+	for i, t := range trainings {
+		infos[i] = ReadDataInfo(t)
+		values[i] = metric(infos[i])
+		maxValue = math.Max(maxValue, values[i])
+		if n := utf8.RuneCountInString(infos[i].TrainingType); n > labelWidth {
+			labelWidth = n
+		}
+	}
+
+	var sb strings.Builder
+	for i, info := range infos {
+		var bar int
+		if maxValue > 0 && values[i] > 0 && width > 0 {
+			bar = int(math.Round(values[i] / maxValue * float64(width)))
+		}
+		fmt.Fprintf(&sb, "%-*s |%s %.2f\n", labelWidth, info.TrainingType, strings.Repeat("#", bar), values[i])
+	}
+	return sb.String()
+}
+
+// WatchFace возвращает короткую сводку тренировки для экрана часов в три строки:
+// крупно дистанция, ниже темп и длительность, затем калории.
+func (i InfoMessage) WatchFace() string {
+	pace := "--:--"
+	if i.Distance > 0 {
+		pace = formatClock(time.Duration(float64(i.Duration) / i.Distance))
+	}
+	return fmt.Sprintf("%s км\n%s /км  %s\n%s ккал\n",
+		formatDecimal(i.Distance, 2),
+		pace,
+		formatClock(i.Duration),
+		formatDecimal(i.Calories, 0),
+	)
+}
+
+// formatClock форматирует продолжительность как "М:СС" или "Ч:ММ:СС", если есть часы.
+func formatClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
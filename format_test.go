@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBarChartCalories(t *testing.T) {
+	trainings := []CaloriesCalculator{
+		Recorded{Training: Training{TrainingType: "Бег", Duration: time.Hour}, RecordedCalories: 100},
+		Recorded{Training: Training{TrainingType: "Ходьба", Duration: time.Hour}, RecordedCalories: 200},
+		Recorded{Training: Training{TrainingType: "Плавание", Duration: time.Hour}, RecordedCalories: 400},
+	}
+	calories := func(i InfoMessage) float64 { return i.Calories }
+
+	want := "Бег      |## 100.00\n" +
+		"Ходьба   |#### 200.00\n" +
+		"Плавание |######## 400.00\n"
+	if got := BarChart(trainings, calories, 8); got != want {
+		t.Errorf("BarChart() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// markdownFormatter форматирует тренировку строкой таблицы Markdown.
+type markdownFormatter struct{}
+
+func (markdownFormatter) Format(i InfoMessage) string {
+	return fmt.Sprintf("| %s | %.2f | %.2f |", i.TrainingType, i.Distance, i.Calories)
+}
+
+func TestReadDataFormatter(t *testing.T) {
+	run := testRun()
+	if got, want := ReadData(run, markdownFormatter{}), "| Бег | 3.25 | 302.91 |"; got != want {
+		t.Errorf("ReadData(markdown) = %q, want %q", got, want)
+	}
+
+	want := ReadDataInfo(run).String()
+	if got := ReadData(run); got != want {
+		t.Errorf("ReadData() = %q, want String() %q", got, want)
+	}
+	if got := ReadData(run, nil); got != want {
+		t.Errorf("ReadData(nil) = %q, want String() %q", got, want)
+	}
+	if got := (DefaultFormatter{}).Format(ReadDataInfo(run)); got != want {
+		t.Errorf("DefaultFormatter.Format() = %q, want %q", got, want)
+	}
+}
+
+func TestWatchFace(t *testing.T) {
+	got := ReadDataInfo(testRun()).WatchFace()
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	want := []string{"3.25 км", "9:14 /км  30:00", "303 ккал"}
+	if len(lines) != len(want) {
+		t.Fatalf("WatchFace() = %q, want %d lines", got, len(want))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+
+	// Калории округляются так же, как в Format без знаков после точки.
+	if got := (InfoMessage{Calories: 702.5}).WatchFace(); !strings.HasSuffix(got, "703 ккал\n") {
+		t.Errorf("WatchFace() for 702.5 kcal = %q, want 703 ккал", got)
+	}
+
+	noDistance := InfoMessage{Duration: 90 * time.Minute, Calories: 200}
+	if got, want := noDistance.WatchFace(), "0.00 км\n--:-- /км  1:30:00\n200 ккал\n"; got != want {
+		t.Errorf("WatchFace() without distance = %q, want %q", got, want)
+	}
+}
+
+func TestStringInUnits(t *testing.T) {
+	info := ReadDataInfo(testRun())
+
+	metric := "Тип тренировки: Бег\nДлительность: 30 мин\nДистанция: 3.25 км.\nСр. скорость: 6.50 км/ч\nПотрачено ккал: 302.91\n"
+	if got := info.StringIn(Metric); got != metric {
+		t.Errorf("StringIn(Metric) = %q, want %q", got, metric)
+	}
+	if got := info.String(); got != metric {
+		t.Errorf("String() = %q, want StringIn(Metric)", got)
+	}
+
+	// 3.25 км = 2.019 ми, 6.5 км/ч = 4.039 ми/ч, калории без изменений.
+	imperial := "Тип тренировки: Бег\nДлительность: 30 мин\nДистанция: 2.02 ми.\nСр. скорость: 4.04 ми/ч\nПотрачено ккал: 302.91\n"
+	if got := info.StringIn(Imperial); got != imperial {
+		t.Errorf("StringIn(Imperial) = %q, want %q", got, imperial)
+	}
+}
+
+func TestFormatOptions(t *testing.T) {
+	info := InfoMessage{
+		TrainingType: "Бег",
+		Duration:     90*time.Minute + 15*time.Second,
+		Distance:     12.345,
+		Speed:        8.2047,
+		Calories:     702.5,
+	}
+	tests := []struct {
+		name string
+		opts FormatOptions
+		want string
+	}{
+		{
+			"zero decimals round half away from zero",
+			FormatOptions{},
+			"Тип тренировки: Бег\nДлительность: 90.25 мин\nДистанция: 12 км.\nСр. скорость: 8 км/ч\nПотрачено ккал: 703\n",
+		},
+		{
+			"human duration",
+			FormatOptions{DistanceDecimals: 1, SpeedDecimals: 1, CaloriesDecimals: 0, HumanDuration: true},
+			"Тип тренировки: Бег\nДлительность: 1ч 30м\nДистанция: 12.3 км.\nСр. скорость: 8.2 км/ч\nПотрачено ккал: 703\n",
+		},
+		{
+			"human duration with seconds",
+			FormatOptions{DistanceDecimals: 3, SpeedDecimals: 2, CaloriesDecimals: 1, HumanDuration: true, ShowSeconds: true},
+			"Тип тренировки: Бег\nДлительность: 1ч 30м 15с\nДистанция: 12.345 км.\nСр. скорость: 8.20 км/ч\nПотрачено ккал: 702.5\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := info.Format(tt.opts); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got, want := info.Format(DefaultFormatOptions()), info.String(); got != want {
+		t.Errorf("Format(DefaultFormatOptions()) = %q, want String() %q", got, want)
+	}
+}
+
+func TestStringMatchesPrintfTemplate(t *testing.T) {
+	for _, v := range []float64{0.125, 100.125, 2.675, 1.005, 0.005, 12.345, 302.9145} {
+		info := InfoMessage{TrainingType: "Бег", Duration: 30 * time.Minute, Distance: v, Speed: v, Calories: v}
+		want := fmt.Sprintf("Тип тренировки: %s\nДлительность: %v мин\nДистанция: %.2f км.\nСр. скорость: %.2f км/ч\nПотрачено ккал: %.2f\n",
+			info.TrainingType, info.Duration.Minutes(), v, v, v)
+		if got := info.String(); got != want {
+			t.Errorf("String() for %v = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestExtendedFormatter(t *testing.T) {
+	info := InfoMessage{TrainingType: "Бег", Duration: 40 * time.Minute, Distance: 8, Speed: 12, Calories: 480}
+	want := info.String() + "Ккал в минуту: 12.00\nКкал на км: 60.00\n"
+	if got := (ExtendedFormatter{}).Format(info); got != want {
+		t.Errorf("ExtendedFormatter.Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatWith(t *testing.T) {
+	info := InfoMessage{TrainingType: "Бег", Duration: 90*time.Minute + 30*time.Second, Distance: 3.25, Speed: 6.5, Calories: 302.9145}
+
+	if got, want := info.FormatWith(FormatConfig{}), info.String(); got != want {
+		t.Errorf("FormatWith(defaults) = %q, want String() %q", got, want)
+	}
+
+	cfg := FormatConfig{DecimalSep: ",", DistanceUnit: "mi", SpeedUnit: "mph"}
+	want := "Тип тренировки: Бег\nДлительность: 90,5 мин\nДистанция: 3,25 mi\nСр. скорость: 6,50 mph\nПотрачено ккал: 302,91\n"
+	if got := info.FormatWith(cfg); got != want {
+		t.Errorf("FormatWith(comma, mi, mph) = %q, want %q", got, want)
+	}
+
+	// Те же поля в FormatOptions сочетаются с остальными настройками вывода.
+	opts := FormatOptions{
+		Units:            Imperial,
+		DistanceDecimals: 1,
+		SpeedDecimals:    1,
+		HumanDuration:    true,
+		DecimalSep:       ",",
+		DistanceUnit:     "mi",
+		SpeedUnit:        "mph",
+	}
+	want = "Тип тренировки: Бег\nДлительность: 1ч 31м\nДистанция: 2,0 mi\nСр. скорость: 4,0 mph\nПотрачено ккал: 303\n"
+	if got := info.Format(opts); got != want {
+		t.Errorf("Format(imperial, comma, human duration) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// KcalPerKgFat приблизительная энергия одного килограмма жировой ткани в ккал.
+const KcalPerKgFat = 7700
+
+// EstimatedWeightChange возвращает изменение веса в кг при накопленном дефиците
+// cumulativeDeficitKcal килокалорий. Дефицит уменьшает вес, поэтому результат
+// для положительного дефицита отрицательный. Оценка исходит из того, что весь дефицит
+// покрывается жиром (~7700 ккал/кг) и не учитывает воду и адаптацию обмена веществ.
+func EstimatedWeightChange(cumulativeDeficitKcal float64) float64 {
+	return -cumulativeDeficitKcal / KcalPerKgFat
+}
+
+// Константы для оценки VO2max по формулам ACSM, мл/кг/мин.
+const (
+	VO2RestingComponent = 3.5                         // потребление кислорода в покое
+	VO2RunningPerMeter  = 0.2                         // на каждый метр в минуту бега
+	VO2WalkingPerMeter  = 0.1                         // на каждый метр в минуту ходьбы
+	VO2MaxSmoothing     = 0.2                         // вес новой тренировки в скользящей оценке
+	KmHInMMin           = float64(MInKm) / MinInHours // коэффициент для перевода км/ч в м/мин
+)
+
+// sessionVO2 возвращает потребление кислорода на тренировке в мл/кг/мин
+// по формулам ACSM. Оценка есть только для бега и ходьбы.
+func sessionVO2(kind TrainingKind, speedKmH float64) (float64, bool) {
+	speed := speedKmH * KmHInMMin
+	switch kind {
+	case KindRunning:
+		return VO2RunningPerMeter*speed + VO2RestingComponent, true
+	case KindWalking:
+		return VO2WalkingPerMeter*speed + VO2RestingComponent, true
+	}
+	return 0, false
+}
+
+// VO2MaxContribution возвращает, на сколько новая тренировка меняет скользящую
+// оценку VO2max в мл/кг/мин. Текущая оценка — среднее VO2 тренировок recent,
+// новая оценка получается экспоненциальным сглаживанием:
+// новая = (1 - 0.2) * текущая + 0.2 * VO2_тренировки.
+// VO2 оценивается только для бега и ходьбы, остальные тренировки оценку не меняют.
+func (i InfoMessage) VO2MaxContribution(recent []CaloriesCalculator) float64 {
+	vo2, ok := sessionVO2(TrainingKind(strings.TrimSpace(i.TrainingType)), i.Speed)
+	if !ok {
+		return 0
+	}
+
+	var sum float64
+	var n int
+	for _, t := range recent {
+		if v, ok := sessionVO2(kindOf(t), t.TrainingInfo().Speed); ok {
+			sum += v
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return VO2MaxSmoothing * (vo2 - sum/float64(n))
+}
+
+// Gender пол пользователя.
+type Gender int
+
+// Значения пола пользователя.
+const (
+	GenderUnset  Gender = iota // не указан
+	GenderMale                 // мужской
+	GenderFemale               // женский
+)
+
+// schofieldCoefficients коэффициенты формулы Шофилда BMR = a * вес_в_кг + b (ккал/сутки)
+// для мужчин и женщин по возрастным группам; upTo — верхняя граница группы, не включая ее.
+var schofieldCoefficients = []struct {
+	upTo             int
+	maleA, maleB     float64
+	femaleA, femaleB float64
+}{
+	{10, 22.706, 504.3, 20.315, 485.9},
+	{18, 17.686, 658.2, 13.384, 692.6},
+	{30, 15.057, 692.2, 14.818, 486.6},
+	{60, 11.472, 873.1, 8.126, 845.6},
+	{math.MaxInt, 11.711, 587.7, 9.082, 658.5},
+}
+
+// schofieldBMR возвращает основной обмен в ккал/сутки по формуле Шофилда,
+// которой нужны только вес, возраст и пол. Для неуказанного пола берется
+// среднее мужского и женского значений.
+func schofieldBMR(weight float64, age int, gender Gender) float64 {
+	for _, c := range schofieldCoefficients {
+		if age >= c.upTo {
+			continue
+		}
+		male := c.maleA*weight + c.maleB
+		female := c.femaleA*weight + c.femaleB
+		switch gender {
+		case GenderMale:
+			return male
+		case GenderFemale:
+			return female
+		}
+		return (male + female) / 2
+	}
+	return 0
+}
+
+// netCalories вычитает из gross килокалории основного обмена за время тренировки.
+// Результат не бывает меньше 0. Без возраста основной обмен не оценить,
+// и возвращается gross.
+func netCalories(gross float64, t Training, age int, gender Gender) float64 {
+	if age <= 0 {
+		return gross
+	}
+	resting := schofieldBMR(t.weight(), age, gender) * t.Duration.Hours() / 24
+	return math.Max(gross-resting, 0)
+}
+
+// NetCalories возвращает килокалории, потраченные при беге сверх основного обмена.
+func (r Running) NetCalories(age int, gender Gender) float64 {
+	return netCalories(r.Calories(), r.Training, age, gender)
+}
+
+// NetCalories возвращает килокалории, потраченные при ходьбе сверх основного обмена.
+func (w Walking) NetCalories(age int, gender Gender) float64 {
+	return netCalories(w.Calories(), w.Training, age, gender)
+}
+
+// NetCalories возвращает килокалории, потраченные при плавании сверх основного обмена.
+func (s Swimming) NetCalories(age int, gender Gender) float64 {
+	return netCalories(s.Calories(), s.Training, age, gender)
+}
+
+// Пороги восстановления пульса за первую минуту после тренировки, уд/мин.
+const (
+	ExcellentHRRecovery = 25 // и выше — отличная тренированность
+	GoodHRRecovery      = 12 // и выше — хорошая; ниже — плохая
+)
+
+// Оценки восстановления пульса.
+const (
+	RecoveryExcellent = "отлично"
+	RecoveryGood      = "хорошо"
+	RecoveryPoor      = "плохо"
+)
+
+// RecoveryScore оценивает тренированность по восстановлению пульса HRRecovery:
+// от 25 уд/мин — отлично, от 12 — хорошо, меньше — плохо.
+// Если восстановление не измерено, возвращается пустая строка.
+func (t Training) RecoveryScore() string {
+	switch {
+	case t.HRRecovery <= 0:
+		return ""
+	case t.HRRecovery >= ExcellentHRRecovery:
+		return RecoveryExcellent
+	case t.HRRecovery >= GoodHRRecovery:
+		return RecoveryGood
+	}
+	return RecoveryPoor
+}
+
+// Допустимые значения для расчета калорий по пульсу.
+const (
+	MinHeartRate = 30  // минимальный средний пульс, уд/мин
+	MaxHeartRate = 250 // максимальный средний пульс, уд/мин
+	MinKeytelAge = 10  // минимальный возраст, лет
+	MaxKeytelAge = 100 // максимальный возраст, лет
+)
+
+// HeartRate данные о пульсе пользователя на тренировке.
+type HeartRate struct {
+	AvgBPM float64 // средний пульс, уд/мин
+	Age    int     // возраст, лет
+	Gender Gender  // пол
+}
+
+// CaloriesFromHeartRate возвращает килокалории, потраченные за время d при пульсе hr
+// и весе weight кг, по формуле Кейтеля (Keytel et al., 2005), в кДж/мин:
+//
+//	мужчины: -55.0969 + 0.6309 * пульс + 0.1988 * вес + 0.2017 * возраст;
+//	женщины: -20.4022 + 0.4472 * пульс - 0.1263 * вес + 0.074 * возраст.
+//
+// Для неуказанного пола берется среднее двух формул. Функция не зависит от типа
+// тренировки. При пульсе или возрасте вне допустимых диапазонов, неположительных
+// весе или длительности возвращается 0.
+func CaloriesFromHeartRate(hr HeartRate, weight float64, d time.Duration) float64 {
+	if InRange(hr.AvgBPM, MinHeartRate, MaxHeartRate, "AvgBPM") != nil ||
+		InRange(float64(hr.Age), MinKeytelAge, MaxKeytelAge, "Age") != nil ||
+		weight <= 0 || d <= 0 {
+		return 0
+	}
+
+	age := float64(hr.Age)
+	male := -55.0969 + 0.6309*hr.AvgBPM + 0.1988*weight + 0.2017*age
+	female := -20.4022 + 0.4472*hr.AvgBPM - 0.1263*weight + 0.074*age
+
+	var kJPerMin float64
+	switch hr.Gender {
+	case GenderMale:
+		kJPerMin = male
+	case GenderFemale:
+		kJPerMin = female
+	default:
+		kJPerMin = (male + female) / 2
+	}
+	return math.Max(kJPerMin/KJInKcal*d.Minutes(), 0)
+}
+
+// Допустимый возраст для расчета основного обмена по формуле Миффлина — Сан Жеора.
+const (
+	MinMifflinAge = 1   // минимальный возраст, лет
+	MaxMifflinAge = 120 // максимальный возраст, лет
+)
+
+// BasalMetabolicRate возвращает основной обмен в ккал/сутки по формуле
+// Миффлина — Сан Жеора:
+//
+//	мужчины: 10 * вес_в_кг + 6.25 * рост_в_см - 5 * возраст + 5;
+//	женщины: 10 * вес_в_кг + 6.25 * рост_в_см - 5 * возраст - 161.
+//
+// При весе, росте или возрасте вне допустимых диапазонов возвращается 0.
+func BasalMetabolicRate(weight, heightCm, ageYears float64, male bool) float64 {
+	if InRange(weight, MinWeight, MaxWeight, "Weight") != nil ||
+		InRange(heightCm, MinHeight, MaxHeight, "Height") != nil ||
+		InRange(ageYears, MinMifflinAge, MaxMifflinAge, "Age") != nil {
+		return 0
+	}
+
+	bmr := 10*weight + 6.25*heightCm - 5*ageYears
+	if male {
+		return bmr + 5
+	}
+	return bmr - 161
+}
+
+// TotalDailyExpenditure возвращает суточный расход энергии в ккал: основной обмен bmr
+// плюс килокалории тренировок за день. Отрицательный bmr считается нулевым.
+// Основной обмен за время тренировок не вычитается, поэтому при длинных
+// тренировках итог немного завышен; см. NetCalories.
+func TotalDailyExpenditure(bmr float64, trainings []CaloriesCalculator) float64 {
+	total := math.Max(bmr, 0)
+	for _, t := range trainings {
+		total += t.Calories()
+	}
+	return total
+}
+
+// Константы для оценки потоотделения.
+const (
+	SweatLatentHeat      = 580  // теплота испарения пота, ккал/л
+	SweatBaseTempC       = 20   // температура воздуха, при которой потом отводится SweatBaseShare тепла, °C
+	SweatBaseShare       = 0.5  // доля затраченной энергии, отводимая потом при SweatBaseTempC
+	SweatSharePerDegreeC = 0.02 // изменение этой доли на каждый градус
+	MinSweatShare        = 0.1  // минимальная доля, отводимая потом в холоде
+)
+
+// SweatRatePerHour возвращает оценку потоотделения в литрах в час при температуре
+// воздуха tempC °C. Почти вся затраченная энергия уходит в тепло, часть его отводится
+// испарением пота; эта доля растет с температурой воздуха:
+//
+//	доля = 0.5 + 0.02 * (температура - 20), от 0.1 до 1;
+//	пот_в_л/ч = ккал_в_минуту * мин_в_часе * доля / 580.
+//
+// Без длительности или калорий возвращается 0.
+func (i InfoMessage) SweatRatePerHour(tempC float64) float64 {
+	share := SweatBaseShare + SweatSharePerDegreeC*(tempC-SweatBaseTempC)
+	share = math.Max(MinSweatShare, math.Min(share, 1))
+	return math.Max(i.CaloriesPerMinute(), 0) * MinInHours * share / SweatLatentHeat
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimatedWeightChange(t *testing.T) {
+	tests := []struct {
+		deficit, want float64
+	}{
+		{7700, -1},
+		{3850, -0.5},
+		{-1540, 0.2},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		if got := EstimatedWeightChange(tt.deficit); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("EstimatedWeightChange(%v) = %v, want %v", tt.deficit, got, tt.want)
+		}
+	}
+}
+
+func TestVO2MaxContributionHardSession(t *testing.T) {
+	recent := []CaloriesCalculator{testRun(), testRun()} // 6.5 км/ч
+	hard := InfoMessage{TrainingType: string(KindRunning), Speed: 12}
+
+	// VO2 при 6.5 км/ч: 0.2 * 108.33 + 3.5 = 25.17; при 12 км/ч: 0.2 * 200 + 3.5 = 43.5.
+	want := VO2MaxSmoothing * (43.5 - (0.2*6500.0/60 + 3.5))
+	got := hard.VO2MaxContribution(recent)
+	if got <= 0 {
+		t.Fatalf("VO2MaxContribution() = %v, want a positive nudge", got)
+	}
+	if !almostEqual(got, want, 1e-9) {
+		t.Errorf("VO2MaxContribution() = %v, want %v", got, want)
+	}
+
+	swim := InfoMessage{TrainingType: string(KindSwimming), Speed: 3}
+	if got := swim.VO2MaxContribution(recent); got != 0 {
+		t.Errorf("VO2MaxContribution() for swimming = %v, want 0", got)
+	}
+}
+
+func TestNetCaloriesRunning(t *testing.T) {
+	run := testRun()
+	gross := run.Calories()
+
+	// Шофилд, мужчина 30–60 лет: 11.472 * 85 + 873.1 = 1848.22 ккал/сутки, за полчаса 38.51.
+	want := gross - 1848.22/48
+	if got := run.NetCalories(30, GenderMale); !almostEqual(got, want, 1e-9) {
+		t.Errorf("NetCalories(30, male) = %v, want %v", got, want)
+	}
+	if net := run.NetCalories(30, GenderMale); net >= gross || net <= 0 {
+		t.Errorf("NetCalories() = %v, want between 0 and gross %v", net, gross)
+	}
+	if got := run.NetCalories(0, GenderMale); got != gross {
+		t.Errorf("NetCalories() without age = %v, want gross %v", got, gross)
+	}
+}
+
+func TestRecoveryScore(t *testing.T) {
+	tests := []struct {
+		hrr  float64
+		want string
+	}{
+		{0, ""},
+		{8, RecoveryPoor},
+		{12, RecoveryGood},
+		{24, RecoveryGood},
+		{25, RecoveryExcellent},
+		{40, RecoveryExcellent},
+	}
+	for _, tt := range tests {
+		tr := Training{HRRecovery: tt.hrr}
+		if got := tr.RecoveryScore(); got != tt.want {
+			t.Errorf("RecoveryScore() with HRRecovery=%v = %q, want %q", tt.hrr, got, tt.want)
+		}
+	}
+}
+
+func TestCaloriesFromHeartRateKeytel(t *testing.T) {
+	tests := []struct {
+		name   string
+		hr     HeartRate
+		weight float64
+		want   float64
+	}{
+		{"male", HeartRate{AvgBPM: 150, Age: 30, Gender: GenderMale}, 80, 881.8},
+		{"female", HeartRate{AvgBPM: 150, Age: 30, Gender: GenderFemale}, 60, 592.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CaloriesFromHeartRate(tt.hr, tt.weight, time.Hour); !almostEqual(got, tt.want, 0.05) {
+				t.Errorf("CaloriesFromHeartRate() = %.2f, want %.1f", got, tt.want)
+			}
+		})
+	}
+
+	if got := CaloriesFromHeartRate(HeartRate{AvgBPM: 150, Age: 5}, 80, time.Hour); got != 0 {
+		t.Errorf("CaloriesFromHeartRate() for age 5 = %v, want 0", got)
+	}
+}
+
+func TestBasalMetabolicRate(t *testing.T) {
+	tests := []struct {
+		name                string
+		weight, height, age float64
+		male                bool
+		want                float64
+	}{
+		{"male", 70, 175, 30, true, 1648.75},
+		{"female", 60, 165, 25, false, 1345.25},
+		{"invalid weight", 0, 175, 30, true, 0},
+		{"invalid height", 70, 0, 30, true, 0},
+		{"invalid age", 70, 175, -1, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BasalMetabolicRate(tt.weight, tt.height, tt.age, tt.male)
+			if !almostEqual(got, tt.want, 1e-9) {
+				t.Errorf("BasalMetabolicRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTotalDailyExpenditure(t *testing.T) {
+	run := testRun()
+	want := 1648.75 + run.Calories()
+	if got := TotalDailyExpenditure(1648.75, []CaloriesCalculator{run}); !almostEqual(got, want, 1e-9) {
+		t.Errorf("TotalDailyExpenditure() = %v, want %v", got, want)
+	}
+	if got := TotalDailyExpenditure(-100, nil); got != 0 {
+		t.Errorf("TotalDailyExpenditure(-100, nil) = %v, want 0", got)
+	}
+}
+
+func TestSweatRatePerHour(t *testing.T) {
+	info := testRun().TrainingInfo() // 302.9145 ккал за 30 минут
+	tests := []struct {
+		tempC, want float64
+	}{
+		{20, 302.9145 * 2 * 0.5 / 580},
+		{30, 302.9145 * 2 * 0.7 / 580},
+	}
+	for _, tt := range tests {
+		if got := info.SweatRatePerHour(tt.tempC); !almostEqual(got, tt.want, 1e-3) {
+			t.Errorf("SweatRatePerHour(%v) = %v, want %v", tt.tempC, got, tt.want)
+		}
+	}
+	if info.SweatRatePerHour(30) <= info.SweatRatePerHour(20) {
+		t.Error("sweat rate must grow with temperature")
+	}
+	if got := (InfoMessage{}).SweatRatePerHour(30); got != 0 {
+		t.Errorf("empty InfoMessage SweatRatePerHour() = %v, want 0", got)
+	}
+}
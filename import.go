@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Recorded тренировка, дистанция и калории которой уже измерены
+// внешним устройством или приложением, а не рассчитываются по формулам.
+type Recorded struct {
+	Training
+	RecordedDistance float64 // измеренная дистанция в км
+	RecordedCalories float64 // измеренные потраченные килокалории
+}
+
+// Calories возвращает измеренные килокалории.
+// Это переопределенный метод Calories() из Training.
+func (r Recorded) Calories() float64 {
+	calories, _ := r.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает измеренные килокалории. Они не рассчитываются по формуле,
+// поэтому ошибки не бывает.
+// Это переопределенный метод CaloriesE() из Training.
+func (r Recorded) CaloriesE() (float64, error) {
+	return r.RecordedCalories, nil
+}
+
+// Distance возвращает измеренную дистанцию в км.
+// Это переопределенный метод Distance() из Training.
+func (r Recorded) Distance() float64 {
+	return r.RecordedDistance
+}
+
+// MeanSpeed возвращает среднюю скорость в км/ч по измеренной дистанции
+// или 0 для тренировки без длительности.
+// Это переопределенный метод MeanSpeed() из Training.
+func (r Recorded) MeanSpeed() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return r.RecordedDistance / r.Duration.Hours()
+}
+
+// TrainingInfo возвращает структуру InfoMessage с измеренными данными тренировки.
+// Это переопределенный метод TrainingInfo() из Training.
+func (r Recorded) TrainingInfo() InfoMessage {
+	return InfoMessage{
+		TrainingType: r.TrainingType,
+		Duration:     r.Duration,
+		Distance:     r.Distance(),
+		Speed:        r.MeanSpeed(),
+		Calories:     r.RecordedCalories,
+	}
+}
+
+// appleHealthTimeLayout формат времени в экспорте Apple Health.
+const appleHealthTimeLayout = "2006-01-02 15:04:05 -0700"
+
+// appleHealthKinds сопоставляет типы тренировок Apple Health с видами тренировок.
+var appleHealthKinds = map[string]TrainingKind{
+	"HKWorkoutActivityTypeRunning":  KindRunning,
+	"HKWorkoutActivityTypeWalking":  KindWalking,
+	"HKWorkoutActivityTypeSwimming": KindSwimming,
+	"HKWorkoutActivityTypeCycling":  KindCycling,
+}
+
+// appleHealthWorkout запись Workout из экспорта Apple Health.
+type appleHealthWorkout struct {
+	ActivityType string `xml:"workoutActivityType,attr"`
+	Duration     string `xml:"duration,attr"`
+	DurationUnit string `xml:"durationUnit,attr"`
+	Distance     string `xml:"totalDistance,attr"`
+	DistanceUnit string `xml:"totalDistanceUnit,attr"`
+	Energy       string `xml:"totalEnergyBurned,attr"`
+	EnergyUnit   string `xml:"totalEnergyBurnedUnit,attr"`
+	StartDate    string `xml:"startDate,attr"`
+}
+
+// ParseAppleHealth возвращает тренировки из XML экспорта Apple Health.
+// Из каждой записи Workout берутся тип, длительность, дистанция, потраченная
+// энергия и время начала; остальные записи экспорта пропускаются.
+// Тренировки возвращаются как Recorded с измеренными дистанцией и калориями.
+func ParseAppleHealth(r io.Reader) ([]CaloriesCalculator, error) {
+	dec := xml.NewDecoder(r)
+	var trainings []CaloriesCalculator
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return trainings, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("разбор экспорта Apple Health: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "Workout" {
+			continue
+		}
+		var w appleHealthWorkout
+		if err := dec.DecodeElement(&w, &start); err != nil {
+			return nil, fmt.Errorf("разбор экспорта Apple Health: %w", err)
+		}
+		training, err := w.recorded()
+		if err != nil {
+			return nil, fmt.Errorf("разбор экспорта Apple Health: %w", err)
+		}
+		trainings = append(trainings, training)
+	}
+}
+
+// recorded возвращает тренировку, описанную записью Workout.
+func (w appleHealthWorkout) recorded() (Recorded, error) {
+	trainingType := string(appleHealthKinds[w.ActivityType])
+	if trainingType == "" {
+		trainingType = strings.TrimPrefix(w.ActivityType, "HKWorkoutActivityType")
+	}
+
+	duration, err := parseAppleHealthValue(w.Duration, w.DurationUnit, map[string]float64{
+		"s": 1, "min": 60, "hr": 3600,
+	})
+	if err != nil {
+		return Recorded{}, fmt.Errorf("длительность: %w", err)
+	}
+	distance, err := parseAppleHealthValue(w.Distance, w.DistanceUnit, map[string]float64{
+		"km": 1, "m": 1.0 / MInKm, "mi": 1.609344,
+	})
+	if err != nil {
+		return Recorded{}, fmt.Errorf("дистанция: %w", err)
+	}
+	energy, err := parseAppleHealthValue(w.Energy, w.EnergyUnit, map[string]float64{
+		"kcal": 1, "Cal": 1, "kJ": 1 / 4.184,
+	})
+	if err != nil {
+		return Recorded{}, fmt.Errorf("энергия: %w", err)
+	}
+
+	var start time.Time
+	if w.StartDate != "" {
+		start, err = time.Parse(appleHealthTimeLayout, w.StartDate)
+		if err != nil {
+			return Recorded{}, fmt.Errorf("время начала: %w", err)
+		}
+	}
+
+	return Recorded{
+		Training: Training{
+			TrainingType: trainingType,
+			Duration:     time.Duration(duration * float64(time.Second)),
+			StartTime:    start,
+		},
+		RecordedDistance: distance,
+		RecordedCalories: energy,
+	}, nil
+}
+
+// parseAppleHealthValue разбирает значение атрибута и переводит его в нужные
+// единицы по таблице множителей. Пустое значение означает 0.
+func parseAppleHealthValue(value, unit string, factors map[string]float64) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	factor, ok := factors[unit]
+	if !ok {
+		return 0, fmt.Errorf("неизвестная единица %q", unit)
+	}
+	return v * factor, nil
+}
+
+// ErrUnparseableLine возвращается для строки журнала, которую не удалось разобрать.
+var ErrUnparseableLine = errors.New("неразборчивая строка")
+
+// ParseText возвращает тренировки из текстового журнала, по одной на строку,
+// например "Бег 5км 30мин 85кг".
+//
+// Грамматика строки: тип тренировки (Бег, Ходьба, Плавание, Велосипед), затем
+// значения с единицами в любом порядке, единица пишется слитно или через пробел,
+// дробная часть — через точку или запятую:
+//
+//	км  — дистанция, обязательно;
+//	мин, ч — длительность, обязательно, можно несколько: "1ч 15мин";
+//	кг  — вес, обязательно;
+//	см  — рост, только для ходьбы, по умолчанию PlanHeight;
+//	м   — длина бассейна, только для плавания, по умолчанию DefaultPoolLength.
+//
+// Пустые строки и строки, начинающиеся с #, пропускаются. Количество шагов,
+// оборотов и пересечений бассейна оценивается по дистанции.
+func ParseText(r io.Reader) ([]CaloriesCalculator, error) {
+	var trainings []CaloriesCalculator
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		t, err := parseTextLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", n, err)
+		}
+		trainings = append(trainings, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return trainings, nil
+}
+
+// parseTextLine разбирает одну строку текстового журнала.
+func parseTextLine(line string) (CaloriesCalculator, error) {
+	fields := strings.Fields(line)
+	kind := TrainingKind(fields[0])
+
+	values := make(map[string]float64)
+	for i := 1; i < len(fields); i++ {
+		number, unit := splitNumberUnit(fields[i])
+		if unit == "" && i+1 < len(fields) {
+			i++
+			unit = fields[i]
+		}
+		v, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+		if err != nil || unit == "" {
+			return nil, fmt.Errorf("%w: %q", ErrUnparseableLine, line)
+		}
+		switch unit {
+		case "км", "мин", "ч", "кг", "см", "м":
+		default:
+			return nil, fmt.Errorf("%w: неизвестная единица %q", ErrUnparseableLine, unit)
+		}
+		values[unit] += v
+	}
+
+	km, weight := values["км"], values["кг"]
+	d := time.Duration((values["ч"]*MinInHours + values["мин"]) * float64(time.Minute))
+	if km <= 0 || weight <= 0 || d <= 0 {
+		return nil, fmt.Errorf("%w: нужны дистанция, длительность и вес: %q", ErrUnparseableLine, line)
+	}
+
+	// withLenStep возвращает общую часть тренировки с шагом lenStep метров.
+	withLenStep := func(lenStep float64) Training {
+		return Training{
+			TrainingType: string(kind),
+			Action:       int(math.Round(km * MInKm / lenStep)),
+			LenStep:      lenStep,
+			Duration:     d,
+			Weight:       weight,
+		}
+	}
+	switch kind {
+	case KindRunning:
+		return Running{Training: withLenStep(LenStep)}, nil
+	case KindWalking:
+		height := values["см"]
+		if height <= 0 {
+			height = PlanHeight
+		}
+		return Walking{Training: withLenStep(LenStep), Height: height}, nil
+	case KindCycling:
+		return Cycling{Training: withLenStep(CyclingLenStep)}, nil
+	case KindSwimming:
+		s := NewSwimFromDistance(km, d, weight)
+		if pool := values["м"]; pool > 0 {
+			s.LengthPool = int(pool)
+			s.CountPool = int(math.Round(km * MInKm / pool))
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: %w: %q", ErrUnparseableLine, ErrUnknownTrainingType, fields[0])
+}
+
+// splitNumberUnit делит значение вида "5км" на число и единицу.
+func splitNumberUnit(field string) (number, unit string) {
+	i := strings.IndexFunc(field, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != ','
+	})
+	if i < 0 {
+		return field, ""
+	}
+	return field[:i], field[i:]
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAppleHealth(t *testing.T) {
+	f, err := os.Open("testdata/apple_health.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	trainings, err := ParseAppleHealth(f)
+	if err != nil {
+		t.Fatalf("ParseAppleHealth() error = %v", err)
+	}
+	if len(trainings) != 3 {
+		t.Fatalf("len = %d, want 3 workouts", len(trainings))
+	}
+
+	tests := []struct {
+		trainingType string
+		duration     time.Duration
+		distance     float64
+		calories     float64
+	}{
+		{string(KindRunning), 30 * time.Minute, 5.2, 410},
+		{string(KindSwimming), 45 * time.Minute, 1.5, 350},
+		{"Yoga", time.Hour, 0, 180},
+	}
+	for i, tt := range tests {
+		info := trainings[i].TrainingInfo()
+		if info.TrainingType != tt.trainingType {
+			t.Errorf("[%d] TrainingType = %q, want %q", i, info.TrainingType, tt.trainingType)
+		}
+		if info.Duration != tt.duration {
+			t.Errorf("[%d] Duration = %v, want %v", i, info.Duration, tt.duration)
+		}
+		if !almostEqual(info.Distance, tt.distance, 1e-9) {
+			t.Errorf("[%d] Distance = %v, want %v", i, info.Distance, tt.distance)
+		}
+		if !almostEqual(info.Calories, tt.calories, 0.01) {
+			t.Errorf("[%d] Calories = %v, want %v", i, info.Calories, tt.calories)
+		}
+	}
+
+	want := time.Date(2024, 3, 1, 4, 0, 0, 0, time.UTC)
+	if start := startTime(trainings[0]); !start.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", start, want)
+	}
+}
+
+func TestParseAppleHealthInvalid(t *testing.T) {
+	const export = `<HealthData><Workout workoutActivityType="HKWorkoutActivityTypeRunning" duration="30" durationUnit="parsec"/></HealthData>`
+	if _, err := ParseAppleHealth(strings.NewReader(export)); err == nil {
+		t.Error("ParseAppleHealth() with unknown unit: want error")
+	}
+}
+
+func TestParseText(t *testing.T) {
+	const log = `# журнал за неделю
+Бег 5км 30мин 85кг
+Ходьба 4,5 км 1ч 15мин 70 кг 180см
+
+Плавание 1.5км 45мин 60кг 50м
+Велосипед 20км 40мин 75кг
+`
+	trainings, err := ParseText(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("ParseText() error = %v", err)
+	}
+	if len(trainings) != 4 {
+		t.Fatalf("len = %d, want 4", len(trainings))
+	}
+
+	run, ok := trainings[0].(Running)
+	if !ok || run.Duration != 30*time.Minute || run.Weight != 85 || !almostEqual(run.Distance(), 5, 0.001) {
+		t.Errorf("trainings[0] = %+v, want a 5 km run in 30 min at 85 kg", trainings[0])
+	}
+	walk, ok := trainings[1].(Walking)
+	if !ok || walk.Duration != 75*time.Minute || walk.Height != 180 || !almostEqual(walk.Distance(), 4.5, 0.001) {
+		t.Errorf("trainings[1] = %+v, want a 4.5 km walk in 1h15m at 180 cm", trainings[1])
+	}
+	swim, ok := trainings[2].(Swimming)
+	if !ok || swim.LengthPool != 50 || swim.CountPool != 30 {
+		t.Errorf("trainings[2] = %+v, want 30 lengths of a 50 m pool", trainings[2])
+	}
+	if _, ok := trainings[3].(Cycling); !ok {
+		t.Errorf("trainings[3] = %T, want Cycling", trainings[3])
+	}
+}
+
+func TestParseTextUnparseable(t *testing.T) {
+	for _, line := range []string{
+		"Бег пять км 30мин 85кг",
+		"Бег 5миль 30мин 85кг",
+		"Бег 5км 85кг",
+		"Йога 5км 30мин 85кг",
+	} {
+		_, err := ParseText(strings.NewReader("Бег 5км 30мин 85кг\n" + line))
+		if !errors.Is(err, ErrUnparseableLine) {
+			t.Errorf("ParseText(%q) error = %v, want ErrUnparseableLine", line, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "строка 2") {
+			t.Errorf("error %q does not mention line 2", err)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// RoundMode способ округления килокалорий.
+type RoundMode int
+
+// Способы округления килокалорий.
+const (
+	RoundNearest RoundMode = iota // до ближайшего целого, по умолчанию
+	RoundFloor                    // вниз, консервативная оценка
+	RoundCeil                     // вверх
+)
+
+// CaloriesRounded возвращает потраченные килокалории, округленные до целого способом mode.
+func (i InfoMessage) CaloriesRounded(mode RoundMode) float64 {
+	switch mode {
+	case RoundFloor:
+		return math.Floor(i.Calories)
+	case RoundCeil:
+		return math.Ceil(i.Calories)
+	}
+	return math.Round(i.Calories)
+}
+
+// FoodUnit продукт, в порциях которого можно выразить потраченные килокалории.
+type FoodUnit string
+
+// Продукты для пересчета килокалорий.
+const (
+	FoodPizzaSlice FoodUnit = "кусок пиццы"
+	FoodBanana     FoodUnit = "банан"
+	FoodDonut      FoodUnit = "пончик"
+	FoodBeer       FoodUnit = "бутылка пива"
+	FoodChocolate  FoodUnit = "плитка шоколада"
+	FoodApple      FoodUnit = "яблоко"
+)
+
+// foodUnitKcal калорийность одной порции продукта в ккал.
+var foodUnitKcal = map[FoodUnit]float64{
+	FoodPizzaSlice: 285,
+	FoodBanana:     105,
+	FoodDonut:      250,
+	FoodBeer:       215,
+	FoodChocolate:  530,
+	FoodApple:      95,
+}
+
+// CaloriesIn возвращает потраченные килокалории в порциях продукта unit,
+// например 570 ккал — это 2 куска пиццы. Для неизвестного продукта возвращается 0.
+func (i InfoMessage) CaloriesIn(unit FoodUnit) float64 {
+	kcal, ok := foodUnitKcal[unit]
+	if !ok {
+		return 0
+	}
+	return i.Calories / kcal
+}
+
+// MaxIntensityFactor верхняя граница фактора интенсивности.
+const MaxIntensityFactor = 2
+
+// IntensityFactor возвращает фактор интенсивности: отношение средней скорости
+// к пороговой скорости thresholdSpeed в км/ч. Значение ограничено диапазоном
+// от 0 до MaxIntensityFactor, при неположительной пороговой скорости возвращается 0.
+func (i InfoMessage) IntensityFactor(thresholdSpeed float64) float64 {
+	if thresholdSpeed <= 0 || i.Speed <= 0 {
+		return 0
+	}
+	return math.Min(i.Speed/thresholdSpeed, MaxIntensityFactor)
+}
+
+// FeatureVectorLen длина вектора признаков тренировки.
+const FeatureVectorLen = 7
+
+// FeatureVector возвращает признаки тренировки для моделей машинного обучения
+// в фиксированном порядке:
+//
+//	0 — длительность в минутах;
+//	1 — дистанция в км;
+//	2 — средняя скорость в км/ч;
+//	3 — потраченные килокалории;
+//	4 — средний пульс, уд/мин;
+//	5 — возраст, лет;
+//	6 — набор высоты, м.
+//
+// InfoMessage не хранит необязательные поля тренировки, поэтому признаки 4–6 равны 0;
+// заполненный вектор возвращает TrainingFeatureVector.
+func (i InfoMessage) FeatureVector() []float64 {
+	v := make([]float64, FeatureVectorLen)
+	v[0] = i.Duration.Minutes()
+	v[1] = i.Distance
+	v[2] = i.Speed
+	v[3] = i.Calories
+	return v
+}
+
+// TrainingFeatureVector возвращает вектор признаков тренировки c в порядке
+// InfoMessage.FeatureVector(), дополненный необязательными полями Training:
+// средним пульсом, возрастом и набором высоты. Незаданные поля дают 0.
+func TrainingFeatureVector(c CaloriesCalculator) []float64 {
+	v := c.TrainingInfo().FeatureVector()
+	if b, ok := c.(trainingBase); ok {
+		t := b.base()
+		v[4] = t.AvgHeartRate
+		v[5] = float64(t.Age)
+		v[6] = t.ElevationGain
+	}
+	return v
+}
+
+// Halves делит тренировку на две равные по времени половины.
+// Нагрузка считается равномерной: каждой половине достается половина дистанции
+// и калорий, а средняя скорость не меняется. InfoMessage не хранит отрезки
+// тренировки, поэтому для тренировки с Segments используйте TrainingHalves.
+func (i InfoMessage) Halves() (first, second InfoMessage) {
+	first, second = i, i
+	first.Duration = i.Duration / 2
+	second.Duration = i.Duration - first.Duration
+	first.Distance, second.Distance = i.Distance/2, i.Distance/2
+	first.Calories, second.Calories = i.Calories/2, i.Calories/2
+	return first, second
+}
+
+// TrainingHalves делит тренировку c на две равные по времени половины.
+// Если тренировка разбита на отрезки Segments, дистанция делится по ним:
+// первой половине достается доля дистанции, пройденная отрезками за первую
+// половину их общего времени, а отрезок на границе делится пропорционально времени.
+// Калории делятся в той же доле, что и дистанция. Без отрезков или без их
+// дистанции результат совпадает с ReadDataInfo(c).Halves().
+func TrainingHalves(c CaloriesCalculator) (first, second InfoMessage) {
+	info := ReadDataInfo(c)
+	b, ok := c.(trainingBase)
+	if !ok {
+		return info.Halves()
+	}
+
+	var total time.Duration
+	var distance float64
+	for _, seg := range b.base().Segments {
+		if seg.Duration > 0 {
+			total += seg.Duration
+			distance += seg.Distance
+		}
+	}
+	if total <= 0 || distance <= 0 {
+		return info.Halves()
+	}
+
+	half := total / 2
+	var elapsed time.Duration
+	var firstDistance float64
+	for _, seg := range b.base().Segments {
+		if seg.Duration <= 0 || elapsed >= half {
+			continue
+		}
+		if rest := half - elapsed; seg.Duration > rest {
+			firstDistance += seg.Distance * float64(rest) / float64(seg.Duration)
+			elapsed = half
+			continue
+		}
+		firstDistance += seg.Distance
+		elapsed += seg.Duration
+	}
+
+	share := firstDistance / distance
+	first, second = info.Halves()
+	first.Distance, second.Distance = info.Distance*share, info.Distance*(1-share)
+	first.Calories, second.Calories = info.Calories*share, info.Calories*(1-share)
+	if first.Duration > 0 {
+		first.Speed = first.Distance / first.Duration.Hours()
+	}
+	if second.Duration > 0 {
+		second.Speed = second.Distance / second.Duration.Hours()
+	}
+	return first, second
+}
+
+// Compare возвращает разницу тренировки other с этой тренировкой по каждому полю:
+// other минус i для длительности, дистанции, скорости, калорий и кругов.
+// Отрицательное значение означает, что во второй тренировке оно меньше.
+// Тип тренировки берется из этой тренировки.
+func (i InfoMessage) Compare(other InfoMessage) InfoMessage {
+	return InfoMessage{
+		TrainingType: i.TrainingType,
+		Duration:     other.Duration - i.Duration,
+		Distance:     other.Distance - i.Distance,
+		Speed:        other.Speed - i.Speed,
+		Calories:     other.Calories - i.Calories,
+		Laps:         other.Laps - i.Laps,
+	}
+}
+
+// CaloriesPerMinute возвращает потраченные килокалории в минуту или 0 без длительности.
+func (i InfoMessage) CaloriesPerMinute() float64 {
+	if i.Duration <= 0 {
+		return 0
+	}
+	return i.Calories / i.Duration.Minutes()
+}
+
+// CaloriesPerKm возвращает потраченные килокалории на километр или 0 без дистанции.
+func (i InfoMessage) CaloriesPerKm() float64 {
+	if i.Distance <= 0 {
+		return 0
+	}
+	return i.Calories / i.Distance
+}
+
+// EfficiencyVsBaseline возвращает отношение килокалорий на километр этой тренировки
+// к килокалориям на километр базовой тренировки пользователя baseline.
+// Если у одной из тренировок нет дистанции или калорий, возвращается 0.
+func (i InfoMessage) EfficiencyVsBaseline(baseline InfoMessage) float64 {
+	base := baseline.CaloriesPerKm()
+	if base <= 0 {
+		return 0
+	}
+	return i.CaloriesPerKm() / base
+}
+
+// Константы для расчета мощности.
+const (
+	JoulesInKcal      = 4184                  // количество джоулей в одной килокалории
+	KJInKcal          = JoulesInKcal / 1000.0 // количество килоджоулей в одной килокалории
+	CyclingEfficiency = 0.24                  // доля затраченной энергии, уходящая в педали
+)
+
+// WattsPerKg возвращает удельную мощность тренировки в Вт/кг для веса weight.
+// Метаболическая мощность — потраченная энергия, деленная на длительность;
+// для велосипеда оценивается механическая мощность на педалях с КПД 24%.
+// Без веса или длительности возвращается 0.
+func (i InfoMessage) WattsPerKg(weight float64) float64 {
+	if weight <= 0 || i.Duration <= 0 {
+		return 0
+	}
+	power := i.Calories * JoulesInKcal / i.Duration.Seconds()
+	if TrainingKind(strings.TrimSpace(i.TrainingType)) == KindCycling {
+		power *= CyclingEfficiency
+	}
+	return power / weight
+}
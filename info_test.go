@@ -0,0 +1,243 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCaloriesRounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		calories float64
+		mode     RoundMode
+		want     float64
+	}{
+		{"nearest down", 302.4, RoundNearest, 302},
+		{"nearest up", 302.5, RoundNearest, 303},
+		{"floor", 302.9, RoundFloor, 302},
+		{"ceil", 302.1, RoundCeil, 303},
+		{"default is nearest", 302.6, RoundMode(42), 303},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := InfoMessage{Calories: tt.calories}
+			if got := i.CaloriesRounded(tt.mode); got != tt.want {
+				t.Errorf("CaloriesRounded(%v) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntensityFactor(t *testing.T) {
+	tests := []struct {
+		name             string
+		speed, threshold float64
+		want             float64
+	}{
+		{"below threshold", 9, 12, 0.75},
+		{"at threshold", 12, 12, 1},
+		{"clamped", 30, 12, MaxIntensityFactor},
+		{"zero threshold", 9, 0, 0},
+		{"negative threshold", 9, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := InfoMessage{Speed: tt.speed}
+			if got := i.IntensityFactor(tt.threshold); got != tt.want {
+				t.Errorf("IntensityFactor(%v) = %v, want %v", tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureVector(t *testing.T) {
+	info := InfoMessage{TrainingType: "Бег", Duration: 45 * time.Minute, Distance: 7.5, Speed: 10, Calories: 512.25}
+	want := []float64{45, 7.5, 10, 512.25, 0, 0, 0}
+	got := info.FeatureVector()
+	if len(got) != FeatureVectorLen {
+		t.Fatalf("len = %d, want %d", len(got), FeatureVectorLen)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FeatureVector()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTrainingFeatureVector(t *testing.T) {
+	run := testRun()
+	run.AvgHeartRate = 150
+	run.Age = 30
+	run.ElevationGain = 120
+
+	info := run.TrainingInfo()
+	want := []float64{30, info.Distance, info.Speed, info.Calories, 150, 30, 120}
+	got := TrainingFeatureVector(run)
+	if len(got) != FeatureVectorLen {
+		t.Fatalf("len = %d, want %d", len(got), FeatureVectorLen)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TrainingFeatureVector()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	bare := TrainingFeatureVector(testRun())
+	for i := 4; i < FeatureVectorLen; i++ {
+		if bare[i] != 0 {
+			t.Errorf("TrainingFeatureVector() of a bare run [%d] = %v, want 0", i, bare[i])
+		}
+	}
+}
+
+func TestHalves(t *testing.T) {
+	i := InfoMessage{TrainingType: "Бег", Duration: 31 * time.Minute, Distance: 5, Speed: 9.68, Calories: 300}
+	first, second := i.Halves()
+
+	if first.Duration+second.Duration != i.Duration {
+		t.Errorf("durations %v + %v, want %v", first.Duration, second.Duration, i.Duration)
+	}
+	if first.Duration != 15*time.Minute+30*time.Second {
+		t.Errorf("first.Duration = %v, want 15m30s", first.Duration)
+	}
+	for _, h := range []InfoMessage{first, second} {
+		if h.Distance != 2.5 || h.Calories != 150 || h.Speed != i.Speed || h.TrainingType != i.TrainingType {
+			t.Errorf("half = %+v, want half distance and calories at the same speed", h)
+		}
+	}
+}
+
+func TestTrainingHalvesSegments(t *testing.T) {
+	run := testRun() // 3.25 км за 30 минут
+	run.Segments = []Segment{
+		{Duration: 10 * time.Minute, Distance: 2},
+		{Duration: 10 * time.Minute, Distance: 1.5},
+		{Duration: 10 * time.Minute, Distance: 1.5},
+	}
+	info := ReadDataInfo(run)
+	first, second := TrainingHalves(run)
+
+	// За первые 15 минут отрезков пройдено 2 + 0.75 км из 5, то есть 55%.
+	if !almostEqual(first.Distance, info.Distance*0.55, 1e-9) || !almostEqual(second.Distance, info.Distance*0.45, 1e-9) {
+		t.Errorf("distances = %v, %v, want 55%% and 45%% of %v", first.Distance, second.Distance, info.Distance)
+	}
+	if !almostEqual(first.Calories+second.Calories, info.Calories, 1e-9) || first.Calories <= second.Calories {
+		t.Errorf("calories = %v, %v, want a bigger first half summing to %v", first.Calories, second.Calories, info.Calories)
+	}
+	if first.Duration != 15*time.Minute || first.Speed <= second.Speed {
+		t.Errorf("first half = %v at %v km/h, want 15m faster than %v km/h", first.Duration, first.Speed, second.Speed)
+	}
+
+	evenFirst, evenSecond := info.Halves()
+	if f, s := TrainingHalves(testRun()); f != evenFirst || s != evenSecond {
+		t.Errorf("TrainingHalves() without segments = %+v, %+v, want even halves", f, s)
+	}
+}
+
+func TestEfficiencyVsBaseline(t *testing.T) {
+	baseline := InfoMessage{Distance: 5, Calories: 400} // 80 ккал/км
+	tests := []struct {
+		name string
+		i    InfoMessage
+		want float64
+	}{
+		{"less per km", InfoMessage{Distance: 10, Calories: 720}, 0.9},
+		{"same", InfoMessage{Distance: 2, Calories: 160}, 1},
+		{"no distance", InfoMessage{Calories: 160}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.EfficiencyVsBaseline(baseline); !almostEqual(got, tt.want, 1e-9) {
+				t.Errorf("EfficiencyVsBaseline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := baseline.EfficiencyVsBaseline(InfoMessage{Calories: 100}); got != 0 {
+		t.Errorf("EfficiencyVsBaseline(no-distance baseline) = %v, want 0", got)
+	}
+}
+
+func TestWattsPerKgCycling(t *testing.T) {
+	// 1000 Вт затраченной мощности за час, на педалях 240 Вт.
+	ride := InfoMessage{TrainingType: "Велосипед", Duration: time.Hour, Calories: 3600.0 * 1000 / JoulesInKcal}
+	if got := ride.WattsPerKg(80); !almostEqual(got, 3, 1e-9) {
+		t.Errorf("WattsPerKg(80) = %v, want 3", got)
+	}
+
+	run := ride
+	run.TrainingType = "Бег"
+	if got := run.WattsPerKg(80); !almostEqual(got, 12.5, 1e-9) {
+		t.Errorf("running WattsPerKg(80) = %v, want metabolic 12.5", got)
+	}
+	if got := ride.WattsPerKg(0); got != 0 {
+		t.Errorf("WattsPerKg(0) = %v, want 0", got)
+	}
+}
+
+func TestCompareSlowAndFastRun(t *testing.T) {
+	slow := ReadDataInfo(testRun()) // 3.25 км за 30 минут, 6.5 км/ч, 302.91 ккал
+	fastRun := testRun()
+	fastRun.Action = 8000
+	fastRun.Duration = 25 * time.Minute
+	fast := ReadDataInfo(fastRun) // 5.2 км за 25 минут, 12.48 км/ч, 481.16 ккал
+
+	d := slow.Compare(fast)
+	if d.Duration != -5*time.Minute {
+		t.Errorf("Duration delta = %v, want -5m", d.Duration)
+	}
+	if !almostEqual(d.Distance, 1.95, 1e-9) {
+		t.Errorf("Distance delta = %v, want 1.95", d.Distance)
+	}
+	if !almostEqual(d.Speed, 5.98, 1e-9) {
+		t.Errorf("Speed delta = %v, want 5.98", d.Speed)
+	}
+	if !almostEqual(d.Calories, 481.16375-302.9145, 1e-9) {
+		t.Errorf("Calories delta = %v, want %v", d.Calories, 481.16375-302.9145)
+	}
+
+	r := fast.Compare(slow)
+	if r.Duration <= 0 || r.Distance >= 0 || r.Speed >= 0 || r.Calories >= 0 {
+		t.Errorf("fast.Compare(slow) = %+v, want longer duration and smaller distance, speed and calories", r)
+	}
+}
+
+func TestCaloriesIn(t *testing.T) {
+	i := InfoMessage{Calories: 570}
+	tests := []struct {
+		unit FoodUnit
+		want float64
+	}{
+		{FoodPizzaSlice, 2},
+		{FoodBanana, 570.0 / 105},
+		{FoodChocolate, 570.0 / 530},
+		{FoodUnit("арбуз"), 0},
+	}
+	for _, tt := range tests {
+		if got := i.CaloriesIn(tt.unit); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("CaloriesIn(%q) = %v, want %v", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestCaloriesPerMinuteAndKm(t *testing.T) {
+	tests := []struct {
+		name             string
+		i                InfoMessage
+		perMinute, perKm float64
+	}{
+		{"normal", InfoMessage{Duration: 40 * time.Minute, Distance: 8, Calories: 480}, 12, 60},
+		{"zero duration", InfoMessage{Distance: 8, Calories: 480}, 0, 60},
+		{"zero distance", InfoMessage{Duration: 40 * time.Minute, Calories: 480}, 12, 0},
+		{"empty", InfoMessage{}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.CaloriesPerMinute(); got != tt.perMinute {
+				t.Errorf("CaloriesPerMinute() = %v, want %v", got, tt.perMinute)
+			}
+			if got := tt.i.CaloriesPerKm(); got != tt.perKm {
+				t.Errorf("CaloriesPerKm() = %v, want %v", got, tt.perKm)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Label подпись в выводе информации о тренировке.
+type Label int
+
+// Подписи в выводе информации о тренировке.
+const (
+	LabelTrainingType Label = iota // тип тренировки
+	LabelDuration                  // длительность
+	LabelMinutes                   // единица длительности
+	LabelDistance                  // дистанция
+	LabelKm                        // единица дистанции
+	LabelSpeed                     // средняя скорость
+	LabelKmH                       // единица скорости
+	LabelCalories                  // потраченные килокалории
+)
+
+// Localizer переводит подписи и названия типов тренировок на язык пользователя.
+type Localizer interface {
+	Label(l Label) string
+	TrainingType(name string) string
+}
+
+// Locale перевод подписей и названий типов тренировок на один язык.
+type Locale struct {
+	Labels        map[Label]string        // подписи
+	TrainingTypes map[TrainingKind]string // названия видов тренировок
+}
+
+// Label возвращает перевод подписи l или пустую строку, если перевода нет.
+func (loc Locale) Label(l Label) string {
+	return loc.Labels[l]
+}
+
+// TrainingType возвращает перевод названия типа тренировки name.
+// Пробелы по краям названия не учитываются; без перевода название возвращается как есть.
+func (loc Locale) TrainingType(name string) string {
+	if translated, ok := loc.TrainingTypes[TrainingKind(strings.TrimSpace(name))]; ok {
+		return translated
+	}
+	return name
+}
+
+// RussianLocale подписи на русском языке, как в InfoMessage.String().
+var RussianLocale = Locale{
+	Labels: map[Label]string{
+		LabelTrainingType: "Тип тренировки",
+		LabelDuration:     "Длительность",
+		LabelMinutes:      "мин",
+		LabelDistance:     "Дистанция",
+		LabelKm:           "км.",
+		LabelSpeed:        "Ср. скорость",
+		LabelKmH:          "км/ч",
+		LabelCalories:     "Потрачено ккал",
+	},
+}
+
+// EnglishLocale подписи и названия типов тренировок на английском языке.
+var EnglishLocale = Locale{
+	Labels: map[Label]string{
+		LabelTrainingType: "Training type",
+		LabelDuration:     "Duration",
+		LabelMinutes:      "min",
+		LabelDistance:     "Distance",
+		LabelKm:           "km",
+		LabelSpeed:        "Avg speed",
+		LabelKmH:          "km/h",
+		LabelCalories:     "Calories burned",
+	},
+	TrainingTypes: map[TrainingKind]string{
+		KindRunning:          "Running",
+		KindWalking:          "Walking",
+		KindSwimming:         "Swimming",
+		KindCycling:          "Cycling",
+		KindRowing:           "Rowing",
+		BrickTrainingType:    "Brick",
+		IntervalTrainingType: "Intervals",
+		MixedTrainingType:    "Mixed",
+	},
+}
+
+// StringLocalized возвращает строку с информацией о проведенной тренировке
+// с подписями и названием типа тренировки от loc. С RussianLocale результат
+// совпадает с String().
+func (i InfoMessage) StringLocalized(loc Localizer) string {
+	return fmt.Sprintf("%s: %s\n%s: %v %s\n%s: %s %s\n%s: %s %s\n%s: %s\n",
+		loc.Label(LabelTrainingType), loc.TrainingType(i.TrainingType),
+		loc.Label(LabelDuration), i.Duration.Minutes(), loc.Label(LabelMinutes),
+		loc.Label(LabelDistance), formatDecimal(i.Distance, 2), loc.Label(LabelKm),
+		loc.Label(LabelSpeed), formatDecimal(i.Speed, 2), loc.Label(LabelKmH),
+		loc.Label(LabelCalories), formatDecimal(i.Calories, 2),
+	)
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestStringLocalized(t *testing.T) {
+	info := testRun().TrainingInfo()
+
+	if got, want := info.StringLocalized(RussianLocale), info.String(); got != want {
+		t.Errorf("StringLocalized(RussianLocale) = %q, want String() %q", got, want)
+	}
+
+	want := "Training type: Running\nDuration: 30 min\nDistance: 3.25 km\nAvg speed: 6.50 km/h\nCalories burned: 302.91\n"
+	if got := info.StringLocalized(EnglishLocale); got != want {
+		t.Errorf("StringLocalized(EnglishLocale) = %q, want %q", got, want)
+	}
+
+	custom := InfoMessage{TrainingType: "Йога"}
+	if got := EnglishLocale.TrainingType(custom.TrainingType); got != "Йога" {
+		t.Errorf("TrainingType(%q) = %q, want the name unchanged", custom.TrainingType, got)
+	}
+}
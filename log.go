@@ -0,0 +1,217 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TrainingLog журнал тренировок.
+// Методы журнала безопасны для одновременного вызова из нескольких горутин.
+// Нулевое значение готово к использованию.
+type TrainingLog struct {
+	mu        sync.Mutex
+	trainings []CaloriesCalculator
+}
+
+// Add добавляет тренировку в журнал.
+func (l *TrainingLog) Add(t CaloriesCalculator) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.trainings = append(l.trainings, t)
+}
+
+// Trainings возвращает копию списка тренировок журнала в порядке добавления.
+func (l *TrainingLog) Trainings() []CaloriesCalculator {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]CaloriesCalculator(nil), l.trainings...)
+}
+
+// TotalCalories возвращает сумму потраченных килокалорий по всем тренировкам журнала.
+func (l *TrainingLog) TotalCalories() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var total float64
+	for _, t := range l.trainings {
+		total += t.Calories()
+	}
+	return total
+}
+
+// SameType сообщает, относятся ли тренировки a и b к одному виду.
+// Виды сравниваются через kindOf, поэтому пробелы по краям названия
+// типа тренировки не влияют на результат.
+func SameType(a, b CaloriesCalculator) bool {
+	return kindOf(a) == kindOf(b)
+}
+
+// DedupBy возвращает тренировки без повторов: из тренировок с одинаковым
+// ключом key остается первая. Порядок тренировок сохраняется.
+func DedupBy(trainings []CaloriesCalculator, key func(CaloriesCalculator) string) []CaloriesCalculator {
+	seen := make(map[string]bool, len(trainings))
+	result := make([]CaloriesCalculator, 0, len(trainings))
+	for _, t := range trainings {
+		k := key(t)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, t)
+	}
+	return result
+}
+
+// Latest возвращает самую позднюю по StartTime тренировку каждого вида.
+// При одинаковом времени начала остается тренировка, встреченная первой.
+func Latest(trainings []CaloriesCalculator) map[TrainingKind]CaloriesCalculator {
+	result := make(map[TrainingKind]CaloriesCalculator)
+	for _, t := range trainings {
+		kind := kindOf(t)
+		if prev, ok := result[kind]; ok && !startTime(t).After(startTime(prev)) {
+			continue
+		}
+		result[kind] = t
+	}
+	return result
+}
+
+// MostRecent возвращает самую позднюю по StartTime тренировку журнала.
+// При одинаковом времени начала остается тренировка, встреченная первой;
+// тренировки без StartTime считаются самыми ранними. Для пустого журнала
+// возвращается false.
+func MostRecent(trainings []CaloriesCalculator) (CaloriesCalculator, bool) {
+	if len(trainings) == 0 {
+		return nil, false
+	}
+	latest := trainings[0]
+	for _, t := range trainings[1:] {
+		if startTime(t).After(startTime(latest)) {
+			latest = t
+		}
+	}
+	return latest, true
+}
+
+// BestWeek возвращает номер недели ISO с наибольшей суммой потраченных килокалорий
+// и эту сумму. Недели разных лет не смешиваются, при равенстве сумм побеждает
+// более ранняя неделя. Тренировки без StartTime пропускаются; если таких
+// тренировок нет, возвращается 0, 0.
+func BestWeek(trainings []CaloriesCalculator) (week int, total float64) {
+	totals := make(map[isoWeek]float64)
+	for _, t := range trainings {
+		start := startTime(t)
+		if start.IsZero() {
+			continue
+		}
+		year, w := start.ISOWeek()
+		totals[isoWeek{year, w}] += t.Calories()
+	}
+
+	var best isoWeek
+	for w, sum := range totals {
+		if best.week == 0 || sum > total || (sum == total && w.before(best)) {
+			best, total = w, sum
+		}
+	}
+	return best.week, total
+}
+
+// isoWeek неделя ISO с годом.
+type isoWeek struct {
+	year, week int
+}
+
+// before сообщает, идет ли неделя w раньше недели other.
+func (w isoWeek) before(other isoWeek) bool {
+	return w.year < other.year || (w.year == other.year && w.week < other.week)
+}
+
+// CaloriesInRange возвращает сумму потраченных килокалорий по тренировкам,
+// начавшимся в промежутке [from, to]; обе границы включаются.
+// Тренировки без StartTime не учитываются.
+func CaloriesInRange(trainings []CaloriesCalculator, from, to time.Time) float64 {
+	var total float64
+	for _, t := range trainings {
+		start := startTime(t)
+		if start.IsZero() || start.Before(from) || start.After(to) {
+			continue
+		}
+		total += t.Calories()
+	}
+	return total
+}
+
+// CumulativeCurve возвращает накопленную сумму потраченных килокалорий по журналу
+// для построения графика: точки упорядочены по времени начала тренировок,
+// Kcal в каждой точке — сумма калорий всех тренировок до нее включительно.
+// Тренировки без StartTime пропускаются, при одинаковом времени начала
+// сохраняется порядок журнала.
+func CumulativeCurve(trainings []CaloriesCalculator) []struct {
+	T    time.Time
+	Kcal float64
+} {
+	dated := make([]CaloriesCalculator, 0, len(trainings))
+	for _, t := range trainings {
+		if !startTime(t).IsZero() {
+			dated = append(dated, t)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return startTime(dated[i]).Before(startTime(dated[j]))
+	})
+
+	curve := make([]struct {
+		T    time.Time
+		Kcal float64
+	}, len(dated))
+	var total float64
+	for i, t := range dated {
+		total += t.Calories()
+		curve[i].T = startTime(t)
+		curve[i].Kcal = total
+	}
+	return curve
+}
+
+// StartOfWeek возвращает полночь первого дня недели, в которую попадает t,
+// в часовом поясе t. Неделя начинается с дня weekStart: time.Monday по ISO,
+// time.Sunday в США.
+func StartOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	year, month, day := t.Date()
+	return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+}
+
+// GroupByWeek группирует тренировки по неделям, начинающимся с дня weekStart,
+// с ключом — началом недели из StartOfWeek. Порядок тренировок внутри недели
+// сохраняется, тренировки без StartTime пропускаются.
+func GroupByWeek(trainings []CaloriesCalculator, weekStart time.Weekday) map[time.Time][]CaloriesCalculator {
+	weeks := make(map[time.Time][]CaloriesCalculator)
+	for _, t := range trainings {
+		start := startTime(t)
+		if start.IsZero() {
+			continue
+		}
+		week := StartOfWeek(start, weekStart)
+		weeks[week] = append(weeks[week], t)
+	}
+	return weeks
+}
+
+// BestWeekStart возвращает начало недели с наибольшей суммой потраченных
+// килокалорий и эту сумму. Недели начинаются с дня weekStart, как в GroupByWeek,
+// при равенстве сумм побеждает более ранняя неделя. Если тренировок со StartTime
+// нет, возвращается нулевое время и 0. BestWeek — то же для недель ISO по номеру.
+func BestWeekStart(trainings []CaloriesCalculator, weekStart time.Weekday) (week time.Time, total float64) {
+	for w, ts := range GroupByWeek(trainings, weekStart) {
+		var sum float64
+		for _, t := range ts {
+			sum += t.Calories()
+		}
+		if week.IsZero() || sum > total || (sum == total && w.Before(week)) {
+			week, total = w, sum
+		}
+	}
+	return week, total
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// runAt возвращает пробежку testRun с временем начала start.
+func runAt(start time.Time) Running {
+	r := testRun()
+	r.StartTime = start
+	return r
+}
+
+func TestDedupByKey(t *testing.T) {
+	first := testRun()
+	duplicate := testRun()
+	duplicate.Weight = 90
+	other := testRun()
+	other.Action = 8000
+
+	got := DedupBy([]CaloriesCalculator{first, duplicate, other}, func(c CaloriesCalculator) string {
+		return strconv.FormatFloat(c.TrainingInfo().Distance, 'f', 3, 64)
+	})
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if r, ok := got[0].(Running); !ok || r.Weight != first.Weight {
+		t.Errorf("got[0] = %v, want the first run", got[0])
+	}
+	if r, ok := got[1].(Running); !ok || r.Action != other.Action {
+		t.Errorf("got[1] = %v, want the longer run", got[1])
+	}
+}
+
+func TestLatest(t *testing.T) {
+	morning := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	evening := morning.Add(10 * time.Hour)
+
+	oldRun := runAt(morning)
+	newRun := runAt(evening)
+	tie := runAt(evening)
+	tie.Weight = 90
+	swim := testSwim(20, 30*time.Minute)
+	swim.StartTime = morning
+
+	got := Latest([]CaloriesCalculator{oldRun, newRun, swim, tie})
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2 kinds", len(got))
+	}
+	if r, ok := got[KindRunning].(Running); !ok || !r.StartTime.Equal(evening) || r.Weight != newRun.Weight {
+		t.Errorf("Latest running = %v, want the first evening run", got[KindRunning])
+	}
+	if !startTime(got[KindSwimming]).Equal(morning) {
+		t.Errorf("Latest swimming starts at %v, want %v", startTime(got[KindSwimming]), morning)
+	}
+	if len(Latest(nil)) != 0 {
+		t.Error("Latest(nil) is not empty")
+	}
+}
+
+// recordedAt возвращает тренировку recordedKcal с калориями kcal и временем начала start.
+func recordedAt(kcal float64, start time.Time) Recorded {
+	r := recordedKcal(kcal)
+	r.StartTime = start
+	return r
+}
+
+func TestBestWeek(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 8, 0, 0, 0, time.UTC) }
+	trainings := []CaloriesCalculator{
+		recordedAt(200, day(1)),  // 9-я неделя
+		recordedAt(300, day(4)),  // 10-я неделя
+		recordedAt(300, day(6)),  // 10-я неделя
+		recordedAt(500, day(11)), // 11-я неделя
+		recordedKcal(1000),       // без времени начала
+	}
+	week, total := BestWeek(trainings)
+	if week != 10 || total != 600 {
+		t.Errorf("BestWeek() = %d, %v, want 10, 600", week, total)
+	}
+
+	week, total = BestWeek([]CaloriesCalculator{recordedAt(400, day(11)), recordedAt(400, day(4))})
+	if week != 10 || total != 400 {
+		t.Errorf("BestWeek() on a tie = %d, %v, want the earlier week 10, 400", week, total)
+	}
+
+	if week, total := BestWeek([]CaloriesCalculator{recordedKcal(1000)}); week != 0 || total != 0 {
+		t.Errorf("BestWeek() without StartTime = %d, %v, want 0, 0", week, total)
+	}
+}
+
+func TestTrainingLogConcurrentAdd(t *testing.T) {
+	const workers, perWorker = 8, 50
+
+	var log TrainingLog
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				log.Add(recordedKcal(10))
+				_ = log.TotalCalories()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := len(log.Trainings()); n != workers*perWorker {
+		t.Errorf("len(Trainings()) = %d, want %d", n, workers*perWorker)
+	}
+	if got, want := log.TotalCalories(), float64(workers*perWorker*10); got != want {
+		t.Errorf("TotalCalories() = %v, want %v", got, want)
+	}
+}
+
+func TestCaloriesInRangeBoundaries(t *testing.T) {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 10, 23, 59, 59, 0, time.UTC)
+	trainings := []CaloriesCalculator{
+		recordedAt(100, from.Add(-time.Second)), // до начала
+		recordedAt(200, from),                   // на левой границе
+		recordedAt(300, from.Add(72*time.Hour)),
+		recordedAt(400, to),                  // на правой границе
+		recordedAt(500, to.Add(time.Second)), // после конца
+		recordedKcal(600),                    // без времени начала
+	}
+	if got := CaloriesInRange(trainings, from, to); got != 900 {
+		t.Errorf("CaloriesInRange() = %v, want 900", got)
+	}
+}
+
+func TestCumulativeCurve(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 8, 0, 0, 0, time.UTC) }
+	trainings := []CaloriesCalculator{
+		recordedAt(300, day(5)),
+		recordedAt(100, day(1)),
+		recordedKcal(1000), // без времени начала
+		recordedAt(200, day(3)),
+	}
+	curve := CumulativeCurve(trainings)
+
+	wantT := []time.Time{day(1), day(3), day(5)}
+	wantKcal := []float64{100, 300, 600}
+	if len(curve) != len(wantT) {
+		t.Fatalf("len = %d, want %d", len(curve), len(wantT))
+	}
+	for i, p := range curve {
+		if !p.T.Equal(wantT[i]) || p.Kcal != wantKcal[i] {
+			t.Errorf("curve[%d] = (%v, %v), want (%v, %v)", i, p.T, p.Kcal, wantT[i], wantKcal[i])
+		}
+	}
+}
+
+func TestSameTypeTrimsSpaces(t *testing.T) {
+	padded := Training{TrainingType: " Бег", Duration: time.Hour, Weight: 70}
+	run := testRun()
+	if !SameType(padded, run) {
+		t.Errorf("SameType(%q, %q) = false, want true", padded.TrainingType, run.TrainingType)
+	}
+	if SameType(run, testSwim(40, 30*time.Minute)) {
+		t.Error("SameType(run, swim) = true, want false")
+	}
+}
+
+// weekLog возвращает тренировки на стыке недель: воскресенье 10 марта 2024 года,
+// понедельник 11-го, суббота 16-го и воскресенье 17-го.
+func weekLog() []CaloriesCalculator {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 8, 0, 0, 0, time.UTC) }
+	return []CaloriesCalculator{
+		recordedAt(200, day(10)),
+		recordedAt(300, day(11)),
+		recordedAt(100, day(16)),
+		recordedAt(250, day(17)),
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	sunday := time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC)
+	if got, want := StartOfWeek(sunday, time.Monday), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("StartOfWeek(Sunday, Monday) = %v, want %v", got, want)
+	}
+	if got, want := StartOfWeek(sunday, time.Sunday), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("StartOfWeek(Sunday, Sunday) = %v, want %v", got, want)
+	}
+}
+
+func TestWeekStartMondayVsSunday(t *testing.T) {
+	week := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		totals    map[time.Time]float64
+		best      time.Time
+		bestTotal float64
+	}{
+		{"Monday", time.Monday, map[time.Time]float64{week(4): 200, week(11): 650}, week(11), 650},
+		{"Sunday", time.Sunday, map[time.Time]float64{week(10): 600, week(17): 250}, week(10), 600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summaries := WeeklySummaries(weekLog(), tt.weekStart)
+			if len(summaries) != len(tt.totals) {
+				t.Errorf("WeeklySummaries() has %d weeks, want %d", len(summaries), len(tt.totals))
+			}
+			for w, total := range tt.totals {
+				if got := summaries[w].TotalCalories; got != total {
+					t.Errorf("week of %s: TotalCalories = %v, want %v", w.Format("2006-01-02"), got, total)
+				}
+			}
+
+			best, total := BestWeekStart(weekLog(), tt.weekStart)
+			if !best.Equal(tt.best) || total != tt.bestTotal {
+				t.Errorf("BestWeekStart() = %s, %v, want %s, %v",
+					best.Format("2006-01-02"), total, tt.best.Format("2006-01-02"), tt.bestTotal)
+			}
+		})
+	}
+
+	if best, total := BestWeekStart([]CaloriesCalculator{recordedKcal(100)}, time.Monday); !best.IsZero() || total != 0 {
+		t.Errorf("BestWeekStart() without StartTime = %v, %v, want zero time, 0", best, total)
+	}
+}
+
+func TestMostRecent(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 8, 0, 0, 0, time.UTC) }
+	trainings := []CaloriesCalculator{
+		recordedAt(100, day(2)),
+		recordedAt(200, day(5)),
+		recordedAt(300, day(3)),
+	}
+	got, ok := MostRecent(trainings)
+	if !ok {
+		t.Fatal("MostRecent() ok = false, want true")
+	}
+	if start := startTime(got); !start.Equal(day(5)) {
+		t.Errorf("MostRecent() start = %v, want %v", start, day(5))
+	}
+	if _, ok := MostRecent(nil); ok {
+		t.Error("MostRecent(nil) ok = true, want false")
+	}
+}
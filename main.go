@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 )
 
@@ -14,95 +15,357 @@ const (
 	CmInM      = 100  // количество сантиметров в одном метре
 )
 
+// TrainingKind вид тренировки, совпадает с названием типа тренировки.
+type TrainingKind string
+
+// Виды тренировок.
+const (
+	KindRunning  TrainingKind = "Бег"
+	KindWalking  TrainingKind = "Ходьба"
+	KindSwimming TrainingKind = "Плавание"
+	KindCycling  TrainingKind = "Велосипед"
+	KindRowing   TrainingKind = "Гребля"
+)
+
+// kindOf возвращает вид тренировки. Для пользовательских типов вид
+// определяется по названию типа тренировки без пробелов по краям.
+func kindOf(c CaloriesCalculator) TrainingKind {
+	switch c.(type) {
+	case Running:
+		return KindRunning
+	case Walking:
+		return KindWalking
+	case Swimming:
+		return KindSwimming
+	case Cycling:
+		return KindCycling
+	case Rowing:
+		return KindRowing
+	}
+	return TrainingKind(strings.TrimSpace(c.TrainingInfo().TrainingType))
+}
+
 // Training общая структура для всех тренировок
 type Training struct {
-	...        	// тип тренировки
-	...         // количество повторов(шаги, гребки при плавании)
-	...       	// длина одного шага или гребка в м
-	... 		// продолжительность тренировки
-	...       	// вес пользователя в кг
+	TrainingType string        // тип тренировки
+	Action       int           // количество повторов(шаги, гребки при плавании)
+	ActionFloat  float64       // дробное количество повторов из внешних данных; если не 0, заменяет Action
+	LenStep      float64       // длина одного шага или гребка в м
+	Duration     time.Duration // продолжительность тренировки
+	Weight       float64       // вес пользователя в кг
+	StartTime    time.Time     // время начала тренировки
+	SpeedCap     float64       // ограничение средней скорости в расчете калорий, км/ч; 0 — без ограничения
+
+	// необязательные поля, уточняющие оценку калорий
+	AvgHeartRate     float64 // средний пульс, уд/мин
+	Age              int     // возраст пользователя, лет
+	ElevationGain    float64 // набор высоты, м
+	HRRecovery       float64 // снижение пульса за первую минуту после тренировки, уд/мин
+	Gender           Gender  // пол пользователя, уточняет калории бега и ходьбы
+	ResistanceFactor float64 // множитель калорий за дополнительное сопротивление (эспандер, наклон); 0 — без сопротивления
+
+	WeightProfile []WeightSegment // вес по отрезкам тренировки, например с утяжелителем
+	Segments      []Segment       // отрезки тренировки с разным темпом
+}
+
+// Segment отрезок тренировки.
+type Segment struct {
+	Duration time.Duration // продолжительность отрезка
+	Distance float64       // дистанция отрезка в км
+}
+
+// pace возвращает темп отрезка в мин/км или 0 без дистанции.
+func (s Segment) pace() float64 {
+	if s.Distance <= 0 {
+		return 0
+	}
+	return s.Duration.Minutes() / s.Distance
+}
+
+// WeightSegment отрезок тренировки с постоянным весом пользователя.
+type WeightSegment struct {
+	Duration time.Duration // продолжительность отрезка
+	Weight   float64       // вес пользователя на отрезке в кг
+}
+
+// weight возвращает вес пользователя для расчета калорий.
+// Все формулы калорий линейны по весу, поэтому интеграл по отрезкам WeightProfile
+// равен расчету со средним весом, взвешенным по продолжительности отрезков.
+// Отрезки обрезаются по Duration, а не покрытое ими время считается с весом Weight.
+// Без отрезков или длительности используется Weight.
+func (t Training) weight() float64 {
+	if t.Duration <= 0 {
+		return t.Weight
+	}
+	var covered time.Duration
+	var weighted float64
+	for _, seg := range t.WeightProfile {
+		if seg.Duration <= 0 || covered >= t.Duration {
+			continue
+		}
+		d := seg.Duration
+		if rest := t.Duration - covered; d > rest {
+			d = rest
+		}
+		covered += d
+		weighted += seg.Weight * d.Hours()
+	}
+	if covered <= 0 {
+		return t.Weight
+	}
+	weighted += t.Weight * (t.Duration - covered).Hours()
+	return weighted / t.Duration.Hours()
+}
+
+// base возвращает общую часть тренировки.
+// Метод продвигается во все типы, встраивающие Training.
+func (t Training) base() Training {
+	return t
+}
+
+// trainingBase реализуют все тренировки, встраивающие Training.
+type trainingBase interface {
+	base() Training
+}
+
+// startTime возвращает время начала тренировки или нулевое время, если оно неизвестно.
+func startTime(c CaloriesCalculator) time.Time {
+	if b, ok := c.(trainingBase); ok {
+		return b.base().StartTime
+	}
+	return time.Time{}
+}
+
+// action возвращает количество повторов: ActionFloat, если оно задано, иначе Action.
+func (t Training) action() float64 {
+	if t.ActionFloat != 0 {
+		return t.ActionFloat
+	}
+	return float64(t.Action)
 }
 
 // distance возвращает дистанцию, которую преодолел пользователь.
 // Формула расчета:
 // количество_повторов * длина_шага / м_в_км
 func (t Training) distance() float64 {
-	// вставьте ваш код ниже
-	...
+	return t.action() * t.LenStep / MInKm
 }
 
 // meanSpeed возвращает среднюю скорость бега или ходьбы.
 func (t Training) meanSpeed() float64 {
-	// вставьте ваш код ниже
-	...	
+	if t.Duration <= 0 {
+		return 0
+	}
+	return t.distance() / t.Duration.Hours()
+}
+
+// Distance возвращает дистанцию тренировки в км, как в TrainingInfo().
+func (t Training) Distance() float64 {
+	return t.distance()
+}
+
+// MeanSpeed возвращает среднюю скорость тренировки в км/ч, как в TrainingInfo().
+func (t Training) MeanSpeed() float64 {
+	return t.meanSpeed()
+}
+
+// GenericMET метаболический эквивалент тренировки неизвестного типа,
+// соответствует легкой активности.
+const GenericMET = 3
+
+// ClampedMeanSpeed возвращает среднюю скорость, ограниченную сверху значением max,
+// чтобы выбросы GPS не завышали расчет. При неположительном max ограничения нет.
+func (t Training) ClampedMeanSpeed(max float64) float64 {
+	return clampSpeed(t.meanSpeed(), max)
+}
+
+// clampSpeed ограничивает скорость speed сверху значением max, если max положительно.
+func clampSpeed(speed, max float64) float64 {
+	if max > 0 && speed > max {
+		return max
+	}
+	return speed
+}
+
+// Константы общих поправок калорий.
+const (
+	YouthAge            = 16  // возраст, до которого применяется детский коэффициент, лет
+	YouthCaloriesFactor = 0.9 // детский коэффициент калорий
+	MaxResistanceFactor = 3   // максимальный множитель калорий за сопротивление
+)
+
+// adjustCalories применяет к калориям, посчитанным по формуле типа тренировки,
+// общие для всех типов поправки:
+//
+//   - формулы калорий выведены для взрослых и завышают затраты детей, поэтому
+//     при известном возрасте младше YouthAge калории умножаются на YouthCaloriesFactor;
+//   - калории умножаются на ResistanceFactor, ограниченный диапазоном
+//     от 1 до MaxResistanceFactor; незаданный множитель равен 1.
+func (t Training) adjustCalories(calories float64) float64 {
+	if t.Age > 0 && t.Age < YouthAge {
+		calories *= YouthCaloriesFactor
+	}
+	if t.ResistanceFactor > 1 {
+		calories *= math.Min(t.ResistanceFactor, MaxResistanceFactor)
+	}
+	return calories
 }
 
 // Calories возвращает количество потраченных килокалорий на тренировке.
-// Пока возвращаем 0, так как этот метод будет переопределяться для каждого типа тренировки.
+// Каждый тип тренировки переопределяет этот метод своей формулой, а для тренировки
+// без типа это грубая оценка по метаболическому эквиваленту легкой активности.
+// Формула расчета:
+// 3 * вес_спортсмена_в_кг * время_тренировки_в_часах
+// Результат всех формул уточняется общими поправками на возраст и сопротивление, см. adjustCalories.
 func (t Training) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	calories, _ := t.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает количество потраченных килокалорий на тренировке по формуле
+// из Calories() и ошибку, если данные тренировки некорректны.
+func (t Training) CaloriesE() (float64, error) {
+	if err := t.validate(); err != nil {
+		return 0, err
+	}
+	return t.adjustCalories(GenericMET * t.weight() * t.Duration.Hours()), nil
 }
 
 // InfoMessage содержит информацию о проведенной тренировке.
 type InfoMessage struct {
-	// добавьте необходимые поля в структуру
-	...     // тип тренировки
-	... 	// длительность тренировки
-	...     // расстояние, которое преодолел пользователь
-	...     // средняя скорость, с которой двигался пользователь
-	...     // количество потраченных килокалорий на тренировке
+	TrainingType string        // тип тренировки
+	Duration     time.Duration // длительность тренировки
+	Distance     float64       // расстояние, которое преодолел пользователь
+	Speed        float64       // средняя скорость, с которой двигался пользователь
+	Calories     float64       // количество потраченных килокалорий на тренировке
+	Laps         int           // количество кругов, только для плавания
 }
 
 // TrainingInfo возвращает труктуру InfoMessage, в которой хранится вся информация о проведенной тренировке.
 func (t Training) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	return InfoMessage{
+		TrainingType: t.TrainingType,
+		Duration:     t.Duration,
+		Distance:     t.distance(),
+		Speed:        t.meanSpeed(),
+		Calories:     t.Calories(),
+	}
 }
 
 // String возвращает строку с информацией о проведенной тренировке.
+// Это то же самое, что StringIn(Metric) и Format(DefaultFormatOptions()).
 func (i InfoMessage) String() string {
-	return fmt.Sprintf("Тип тренировки: %s\nДлительность: %v мин\nДистанция: %.2f км.\nСр. скорость: %.2f км/ч\nПотрачено ккал: %.2f\n",
-		i.TrainingType,
-		i.Duration.Minutes(),
-		i.Distance,
-		i.Speed,
-		i.Calories,
-	)
+	return i.Format(DefaultFormatOptions())
 }
 
 // CaloriesCalculator интерфейс для структур: Running, Walking и Swimming.
 type CaloriesCalculator interface {
-	// добавьте необходимые методы в интерфейс
-	...
+	Calories() float64
+	TrainingInfo() InfoMessage
 }
 
 // Константы для расчета потраченных килокалорий при беге.
 const (
 	CaloriesMeanSpeedMultiplier = 18   // множитель средней скорости бега
 	CaloriesMeanSpeedShift      = 1.79 // коэффициент изменения средней скорости
+	MaxWindResistancePct        = 50   // максимальная доля встречного ветра в процентах
+)
+
+// RunType вид пробежки по интенсивности.
+type RunType int
+
+// Виды пробежек.
+const (
+	RunDefault  RunType = iota // не указан, без поправки
+	RunEasy                    // легкая
+	RunTempo                   // темповая
+	RunInterval                // интервальная
+	RunRecovery                // восстановительная
 )
 
+// runTypeFactors поправочные множители калорий для видов пробежек: при той же
+// средней скорости темповые и интервальные пробежки затратнее за счет ускорений,
+// а легкие и восстановительные — экономнее.
+var runTypeFactors = map[RunType]float64{
+	RunEasy:     0.95,
+	RunTempo:    1.05,
+	RunInterval: 1.1,
+	RunRecovery: 0.9,
+}
+
+// genderFactors поправочные множители калорий бега и ходьбы для пола пользователя.
+// Формулы калорий выведены по смешанным выборкам; при том же весе и скорости
+// затраты мужчин немного выше, а женщин — ниже за счет разницы в экономичности
+// передвижения. Для неуказанного пола поправки нет.
+var genderFactors = map[TrainingKind]map[Gender]float64{
+	KindRunning: {GenderMale: 1.02, GenderFemale: 0.96},
+	KindWalking: {GenderMale: 1.01, GenderFemale: 0.97},
+}
+
+// genderFactor возвращает множитель калорий тренировки вида kind для пола gender
+// или 1, если поправки нет.
+func genderFactor(kind TrainingKind, gender Gender) float64 {
+	if factor, ok := genderFactors[kind][gender]; ok {
+		return factor
+	}
+	return 1
+}
+
 // Running структура, описывающая тренировку Бег.
 type Running struct {
-	// добавьте необходимые поля в структуру
-	...
+	Training
+	WindResistancePct float64 // дополнительное сопротивление встречного ветра в процентах
+	RunType           RunType // вид пробежки по интенсивности
 }
 
 // Calories возввращает количество потраченных килокалория при беге.
 // Формула расчета:
 // ((18 * средняя_скорость_в_км/ч + 1.79) * вес_спортсмена_в_кг / м_в_км * время_тренировки_в_часах * мин_в_часе)
+// С учетом встречного ветра результат умножается на (1 + сопротивление_ветра_в_процентах / 100),
+// а для указанного вида пробежки — на его поправочный множитель из runTypeFactors.
+// Для указанного пола результат умножается на множитель из genderFactors.
 // Это переопределенный метод Calories() из Training.
 func (r Running) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	calories, _ := r.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает количество потраченных килокалорий при беге по формуле
+// из Calories() и ошибку, если данные тренировки некорректны.
+func (r Running) CaloriesE() (float64, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+	calories := (CaloriesMeanSpeedMultiplier*r.ClampedMeanSpeed(r.SpeedCap) + CaloriesMeanSpeedShift) * r.weight() / MInKm * r.Duration.Hours() * MinInHours
+	calories *= 1 + r.WindResistancePct/100
+	if factor, ok := runTypeFactors[r.RunType]; ok {
+		calories *= factor
+	}
+	calories *= genderFactor(KindRunning, r.Gender)
+	return r.adjustCalories(calories), nil
 }
 
 // TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
 // Это переопределенный метод TrainingInfo() из Training.
 func (r Running) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	info := r.Training.TrainingInfo()
+	info.Calories = r.Calories()
+	return info
+}
+
+// AdjustForWind возвращает копию пробежки с учетом встречного ветра.
+// Модель: ветер добавляет к затратам энергии долю windResistancePct процентов,
+// на столько же процентов растут потраченные килокалории. Значение ограничивается
+// диапазоном от 0 до MaxWindResistancePct, попутный ветер не учитывается.
+func (r Running) AdjustForWind(windResistancePct float64) Running {
+	switch {
+	case windResistancePct < 0:
+		windResistancePct = 0
+	case windResistancePct > MaxWindResistancePct:
+		windResistancePct = MaxWindResistancePct
+	}
+	r.WindResistancePct = windResistancePct
+	return r
 }
 
 // Константы для расчета потраченных килокалорий при ходьбе.
@@ -110,30 +373,94 @@ const (
 	CaloriesWeightMultiplier      = 0.035 // коэффициент для веса
 	CaloriesSpeedHeightMultiplier = 0.029 // коэффициент для роста
 	KmHInMsec                     = 0.278 // коэффициент для перевода км/ч в м/с
+	StepLengthHeightRatio         = 0.415 // отношение длины шага к росту
 )
 
 // Walking структура описывающая тренировку Ходьба
 type Walking struct {
-	// добавьте необходимые поля в структуру
-	...
-	... // рост пользователя
+	Training
+	Height float64 // рост пользователя
+}
+
+// EstimateStepLength возвращает примерную длину шага в м для роста heightCm в см.
+// Формула расчета:
+// рост_в_см * 0.415 / см_в_м
+func EstimateStepLength(heightCm float64) float64 {
+	if heightCm <= 0 {
+		return 0
+	}
+	return heightCm * StepLengthHeightRatio / CmInM
+}
+
+// distance возвращает дистанцию, которую прошел пользователь.
+// Если длина шага не задана, она оценивается по росту пользователя.
+// Это переопределенный метод distance() из Training.
+func (w Walking) distance() float64 {
+	if w.LenStep <= 0 && w.Height > 0 {
+		return w.action() * EstimateStepLength(w.Height) / MInKm
+	}
+	return w.Training.distance()
+}
+
+// meanSpeed возвращает среднюю скорость ходьбы с учетом оценки длины шага.
+// Это переопределенный метод meanSpeed() из Training.
+func (w Walking) meanSpeed() float64 {
+	if w.Duration <= 0 {
+		return 0
+	}
+	return w.distance() / w.Duration.Hours()
+}
+
+// Distance возвращает дистанцию ходьбы в км с учетом оценки длины шага.
+// Это переопределенный метод Distance() из Training.
+func (w Walking) Distance() float64 {
+	return w.distance()
+}
+
+// MeanSpeed возвращает среднюю скорость ходьбы в км/ч с учетом оценки длины шага.
+// Это переопределенный метод MeanSpeed() из Training.
+func (w Walking) MeanSpeed() float64 {
+	return w.meanSpeed()
+}
+
+// ClampedMeanSpeed возвращает среднюю скорость ходьбы, ограниченную сверху значением max.
+// Это переопределенный метод ClampedMeanSpeed() из Training.
+func (w Walking) ClampedMeanSpeed(max float64) float64 {
+	return clampSpeed(w.meanSpeed(), max)
 }
 
 // Calories возвращает количество потраченных килокалорий при ходьбе.
 // Формула расчета:
 // ((0.035 * вес_спортсмена_в_кг + (средняя_скорость_в_метрах_в_секунду**2 / рост_в_метрах)
 // * 0.029 * вес_спортсмена_в_кг) * время_тренировки_в_часах * мин_в_ч)
+// Для указанного пола результат умножается на множитель из genderFactors.
 // Это переопределенный метод Calories() из Training.
 func (w Walking) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	calories, _ := w.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает количество потраченных килокалорий при ходьбе по формуле
+// из Calories() и ошибку, если данные тренировки некорректны.
+func (w Walking) CaloriesE() (float64, error) {
+	if err := w.validate(); err != nil {
+		return 0, err
+	}
+	speed := w.ClampedMeanSpeed(w.SpeedCap) * KmHInMsec
+	height := w.Height / CmInM
+	calories := (CaloriesWeightMultiplier*w.weight() + (math.Pow(speed, 2)/height)*CaloriesSpeedHeightMultiplier*w.weight()) * w.Duration.Hours() * MinInHours
+	calories *= genderFactor(KindWalking, w.Gender)
+	return w.adjustCalories(calories), nil
 }
 
 // TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
 // Это переопределенный метод TrainingInfo() из Training.
 func (w Walking) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	info := w.Training.TrainingInfo()
+	info.Distance = w.distance()
+	info.Speed = w.meanSpeed()
+	info.Calories = w.Calories()
+	return info
 }
 
 // Константы для расчета потраченных килокалорий при плавании.
@@ -143,12 +470,21 @@ const (
 	SwimmingCaloriesWeightMultiplier = 2    // множитель веса пользователя
 )
 
-// Swimming структура, описывающая тренировку Плавание
+// Swimming структура, описывающая тренировку Плавание.
+// Дистанция и скорость плавания всегда считаются по бассейну: Action хранит
+// количество гребков только для справки, а LenStep при плавании не используется.
 type Swimming struct {
-	// добавьте необходимые поля в структуру
-	...
-	... // длина бассейна
-	... // количество пересечений бассейна
+	Training
+	LengthPool int // длина бассейна
+	CountPool  int // количество пересечений бассейна
+}
+
+// distance возвращает дистанцию, которую проплыл пользователь.
+// Формула расчета:
+// длина_бассейна * количество_пересечений / м_в_км
+// Это переопределенный метод distance() из Training.
+func (s Swimming) distance() float64 {
+	return float64(s.LengthPool*s.CountPool) / MInKm
 }
 
 // meanSpeed возвращает среднюю скорость при плавании.
@@ -156,8 +492,29 @@ type Swimming struct {
 // длина_бассейна * количество_пересечений / м_в_км / продолжительность_тренировки
 // Это переопределенный метод Calories() из Training.
 func (s Swimming) meanSpeed() float64 {
-	// вставьте ваш код ниже
-	...
+	if s.Duration <= 0 {
+		return 0
+	}
+	return s.distance() / s.Duration.Hours()
+}
+
+// Distance возвращает дистанцию плавания в км по бассейну.
+// Это переопределенный метод Distance() из Training.
+func (s Swimming) Distance() float64 {
+	return s.distance()
+}
+
+// MeanSpeed возвращает среднюю скорость плавания в км/ч по бассейну.
+// Это переопределенный метод MeanSpeed() из Training.
+func (s Swimming) MeanSpeed() float64 {
+	return s.meanSpeed()
+}
+
+// ClampedMeanSpeed возвращает среднюю скорость плавания по бассейну,
+// ограниченную сверху значением max.
+// Это переопределенный метод ClampedMeanSpeed() из Training.
+func (s Swimming) ClampedMeanSpeed(max float64) float64 {
+	return clampSpeed(s.meanSpeed(), max)
 }
 
 // Calories возвращает количество калорий, потраченных при плавании.
@@ -165,28 +522,209 @@ func (s Swimming) meanSpeed() float64 {
 // (средняя_скорость_в_км/ч + SwimmingCaloriesMeanSpeedShift) * SwimmingCaloriesWeightMultiplier * вес_спортсмена_в_кг * время_тренировки_в_часах
 // Это переопределенный метод Calories() из Training.
 func (s Swimming) Calories() float64 {
-	// вставьте ваш код ниже
-	...
+	calories, _ := s.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает количество потраченных килокалорий при плавании по формуле
+// из Calories() и ошибку, если данные тренировки некорректны.
+func (s Swimming) CaloriesE() (float64, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	calories := (s.ClampedMeanSpeed(s.SpeedCap) + SwimmingCaloriesMeanSpeedShift) * SwimmingCaloriesWeightMultiplier * s.weight() * s.Duration.Hours()
+	return s.adjustCalories(calories), nil
 }
 
 // TrainingInfo returns info about swimming training.
 // Это переопределенный метод TrainingInfo() из Training.
 func (s Swimming) TrainingInfo() InfoMessage {
-	// вставьте ваш код ниже
-	...
+	return InfoMessage{
+		TrainingType: s.TrainingType,
+		Duration:     s.Duration,
+		Distance:     s.distance(),
+		Speed:        s.meanSpeed(),
+		Calories:     s.Calories(),
+		Laps:         s.Laps(),
+	}
 }
 
-// ReadData возвращает информацию о проведенной тренировке.
-func ReadData(training CaloriesCalculator) string {
-	// получите количество затраченных калорий
-	calories := ...
+// Laps возвращает количество кругов: круг — это два пересечения бассейна, туда и обратно.
+// Незаконченный круг при нечетном количестве пересечений не учитывается.
+func (s Swimming) Laps() int {
+	if s.CountPool <= 0 {
+		return 0
+	}
+	return s.CountPool / 2
+}
+
+// Константы для расчета потраченных килокалорий при езде на велосипеде.
+const (
+	CyclingLenStep                     = 5.5 // расстояние за один оборот педалей
+	CyclingCaloriesMeanSpeedMultiplier = 7   // множитель средней скорости езды
+	CyclingCaloriesMeanSpeedShift      = 1.5 // коэффициент изменения средней скорости
+)
+
+// Cycling структура, описывающая тренировку Велосипед.
+// Action — количество оборотов педалей, LenStep — расстояние за один оборот в м.
+type Cycling struct {
+	Training
+}
+
+// Calories возвращает количество потраченных килокалорий при езде на велосипеде.
+// Формула расчета:
+// ((7 * средняя_скорость_в_км/ч + 1.5) * вес_спортсмена_в_кг / м_в_км * время_тренировки_в_часах * мин_в_часе)
+// Это переопределенный метод Calories() из Training.
+func (c Cycling) Calories() float64 {
+	calories, _ := c.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает количество потраченных килокалорий при езде на велосипеде
+// по формуле из Calories() и ошибку, если данные тренировки некорректны.
+func (c Cycling) CaloriesE() (float64, error) {
+	if err := c.validate(); err != nil {
+		return 0, err
+	}
+	calories := (CyclingCaloriesMeanSpeedMultiplier*c.ClampedMeanSpeed(c.SpeedCap) + CyclingCaloriesMeanSpeedShift) * c.weight() / MInKm * c.Duration.Hours() * MinInHours
+	return c.adjustCalories(calories), nil
+}
+
+// TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
+// Это переопределенный метод TrainingInfo() из Training.
+func (c Cycling) TrainingInfo() InfoMessage {
+	info := c.Training.TrainingInfo()
+	info.Calories = c.Calories()
+	return info
+}
+
+// Константы для расчета потраченных килокалорий при гребле.
+const (
+	RowingLenStep            = 10  // проход лодки за один гребок
+	RowingCaloriesMultiplier = 12  // множитель средней скорости гребли
+	RowingCaloriesShift      = 1.2 // коэффициент изменения средней скорости
+)
+
+// Rowing структура, описывающая тренировку Гребля.
+// Action — количество гребков, LenStep — проход лодки за один гребок в м.
+type Rowing struct {
+	Training
+}
+
+// Calories возвращает количество потраченных килокалорий при гребле.
+// Формула расчета:
+// ((12 * средняя_скорость_в_км/ч + 1.2) * вес_спортсмена_в_кг / м_в_км * время_тренировки_в_часах * мин_в_часе)
+// Это переопределенный метод Calories() из Training.
+func (r Rowing) Calories() float64 {
+	calories, _ := r.CaloriesE()
+	return calories
+}
+
+// CaloriesE возвращает количество потраченных килокалорий при гребле
+// по формуле из Calories() и ошибку, если данные тренировки некорректны.
+func (r Rowing) CaloriesE() (float64, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+	calories := (RowingCaloriesMultiplier*r.ClampedMeanSpeed(r.SpeedCap) + RowingCaloriesShift) * r.weight() / MInKm * r.Duration.Hours() * MinInHours
+	return r.adjustCalories(calories), nil
+}
+
+// TrainingInfo возвращает структуру InfoMessage с информацией о проведенной тренировке.
+// Это переопределенный метод TrainingInfo() из Training.
+func (r Rowing) TrainingInfo() InfoMessage {
+	info := r.Training.TrainingInfo()
+	info.Calories = r.Calories()
+	return info
+}
+
+// BrickTrainingType тип тренировки брик.
+const BrickTrainingType = "Брик"
+
+// Brick структура, описывающая тренировку брик: велосипед, сразу за ним бег.
+type Brick struct {
+	Cycling    Cycling
+	Running    Running
+	Transition time.Duration // время перехода с велосипеда на бег
+}
 
-	// получите информацию о тренировке
-	info := ...
-	// добавьте полученные калории в структуру с информацией о тренировке
-	...
+// Calories возвращает сумму килокалорий, потраченных на велосипеде и при беге.
+// Переход между этапами в калориях не учитывается.
+func (b Brick) Calories() float64 {
+	return b.Cycling.Calories() + b.Running.Calories()
+}
+
+// TrainingInfo возвращает структуру InfoMessage с общей информацией о брике:
+// длительность включает переход, дистанция — сумма этапов.
+func (b Brick) TrainingInfo() InfoMessage {
+	duration := b.Cycling.Duration + b.Transition + b.Running.Duration
+	distance := b.Cycling.distance() + b.Running.distance()
+
+	var speed float64
+	if duration > 0 {
+		speed = distance / duration.Hours()
+	}
+	return InfoMessage{
+		TrainingType: BrickTrainingType,
+		Duration:     duration,
+		Distance:     distance,
+		Speed:        speed,
+		Calories:     b.Calories(),
+	}
+}
+
+// IntervalTrainingType тип интервальной тренировки.
+const IntervalTrainingType = "Интервалы"
+
+// Interval структура, описывающая интервальную тренировку: последовательность
+// отрезков, каждый со своим типом тренировки, продолжительностью и повторами,
+// например разминочная ходьба и несколько беговых отрезков.
+type Interval struct {
+	Segments []CaloriesCalculator // отрезки тренировки по порядку
+}
+
+// Calories возвращает сумму килокалорий по всем отрезкам,
+// каждый отрезок считается по формуле своего типа тренировки.
+func (in Interval) Calories() float64 {
+	var total float64
+	for _, seg := range in.Segments {
+		total += seg.Calories()
+	}
+	return total
+}
+
+// TrainingInfo возвращает структуру InfoMessage с общей информацией об интервальной
+// тренировке: длительность и дистанция — суммы по отрезкам, средняя скорость —
+// общая дистанция, деленная на общую длительность.
+func (in Interval) TrainingInfo() InfoMessage {
+	info := InfoMessage{TrainingType: IntervalTrainingType}
+	for _, seg := range in.Segments {
+		segInfo := seg.TrainingInfo()
+		info.Duration += segInfo.Duration
+		info.Distance += segInfo.Distance
+		info.Calories += segInfo.Calories
+	}
+	if info.Duration > 0 {
+		info.Speed = info.Distance / info.Duration.Hours()
+	}
+	return info
+}
+
+// ReadData возвращает информацию о проведенной тренировке.
+// Необязательный formatter задает формат вывода, по умолчанию используется DefaultFormatter.
+func ReadData(training CaloriesCalculator, formatter ...InfoFormatter) string {
+	info := ReadDataInfo(training)
+	if len(formatter) > 0 && formatter[0] != nil {
+		return formatter[0].Format(info)
+	}
+	return DefaultFormatter{}.Format(info)
+}
 
-	return fmt.Sprint(info)
+// ReadDataInfo возвращает структуру InfoMessage с информацией о тренировке.
+// Каждый тип тренировки заполняет в TrainingInfo() калории своим методом Calories().
+// В отличие от ReadData результат не форматируется.
+func ReadDataInfo(training CaloriesCalculator) InfoMessage {
+	return training.TrainingInfo()
 }
 
 func main() {
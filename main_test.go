@@ -0,0 +1,459 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// almostEqual сообщает, отличаются ли a и b не больше чем на tolerance.
+func almostEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestAdjustForWind(t *testing.T) {
+	run := testRun()
+	base := run.Calories()
+
+	tests := []struct {
+		pct, wantPct float64
+	}{
+		{10, 10},
+		{80, MaxWindResistancePct},
+		{-20, 0},
+	}
+	for _, tt := range tests {
+		windy := run.AdjustForWind(tt.pct)
+		if windy.WindResistancePct != tt.wantPct {
+			t.Errorf("AdjustForWind(%v).WindResistancePct = %v, want %v", tt.pct, windy.WindResistancePct, tt.wantPct)
+		}
+		if want := base * (1 + tt.wantPct/100); !almostEqual(windy.Calories(), want, 1e-9) {
+			t.Errorf("AdjustForWind(%v).Calories() = %v, want %v", tt.pct, windy.Calories(), want)
+		}
+	}
+	if run.WindResistancePct != 0 {
+		t.Error("AdjustForWind modified the original run")
+	}
+}
+
+func TestWeightProfileVestHalfSession(t *testing.T) {
+	vest := testRun()
+	vest.WeightProfile = []WeightSegment{
+		{Duration: 15 * time.Minute, Weight: 95},
+		{Duration: 15 * time.Minute, Weight: 85},
+	}
+	mean := testRun()
+	mean.Weight = 90
+
+	if !almostEqual(vest.Calories(), mean.Calories(), 1e-9) {
+		t.Errorf("Calories() with vest = %v, want %v as for the mean weight", vest.Calories(), mean.Calories())
+	}
+	if vest.Calories() <= testRun().Calories() {
+		t.Error("vest does not increase calories")
+	}
+}
+
+func TestWeightProfileVestPartOfSession(t *testing.T) {
+	vest := testRun()
+	vest.Duration = time.Hour
+	vest.Weight = 80
+	vest.WeightProfile = []WeightSegment{{Duration: 30 * time.Minute, Weight: 90}}
+	mean := vest
+	mean.WeightProfile = nil
+	mean.Weight = 85
+
+	if !almostEqual(vest.Calories(), mean.Calories(), 1e-9) {
+		t.Errorf("Calories() with a vest for half the hour = %v, want %v as for 85 kg", vest.Calories(), mean.Calories())
+	}
+
+	// Отрезки длиннее тренировки обрезаются: 45 минут по 90 кг и 15 минут по 100 кг.
+	vest.WeightProfile = []WeightSegment{
+		{Duration: 45 * time.Minute, Weight: 90},
+		{Duration: 45 * time.Minute, Weight: 100},
+	}
+	mean.Weight = 92.5
+	if !almostEqual(vest.Calories(), mean.Calories(), 1e-9) {
+		t.Errorf("Calories() with segments past Duration = %v, want %v as for 92.5 kg", vest.Calories(), mean.Calories())
+	}
+}
+
+func TestWeightProfileSingleWeight(t *testing.T) {
+	run := testRun()
+	run.WeightProfile = []WeightSegment{{Duration: run.Duration, Weight: run.Weight}}
+	if got, want := run.Calories(), testRun().Calories(); !almostEqual(got, want, 1e-9) {
+		t.Errorf("Calories() = %v, want %v", got, want)
+	}
+}
+
+func TestSwimmingDistanceIsSpeedTimesDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   int
+		lenStep  float64
+		duration time.Duration
+	}{
+		{"strokes match the pool", 1450, SwimmingLenStep, 40 * time.Minute},
+		{"strokes ignored", 10, SwimmingLenStep, 40 * time.Minute},
+		{"no LenStep", 1450, 0, 50 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSwim(80, tt.duration)
+			s.Action, s.LenStep = tt.action, tt.lenStep
+
+			info := s.TrainingInfo()
+			if info.Distance != 2 {
+				t.Errorf("Distance = %v, want 2 km from 80 x 25 m", info.Distance)
+			}
+			if got := info.Speed * tt.duration.Hours(); !almostEqual(got, info.Distance, 1e-9) {
+				t.Errorf("Speed * Duration = %v, want Distance %v", got, info.Distance)
+			}
+		})
+	}
+}
+
+// testBrick возвращает брик: 20 км на велосипеде за 40 минут, 5 минут перехода
+// и 5 км бега за 25 минут.
+func testBrick() Brick {
+	return Brick{
+		Cycling:    testRide(3636, 40*time.Minute),
+		Running:    NewRunFromPace(5, 25*time.Minute, 70),
+		Transition: 5 * time.Minute,
+	}
+}
+
+func TestBrick(t *testing.T) {
+	b := testBrick()
+	info := b.TrainingInfo()
+
+	if info.TrainingType != BrickTrainingType {
+		t.Errorf("TrainingType = %q, want %q", info.TrainingType, BrickTrainingType)
+	}
+	if info.Duration != 70*time.Minute {
+		t.Errorf("Duration = %v, want 1h10m including the transition", info.Duration)
+	}
+	if !almostEqual(info.Distance, 25, 0.01) {
+		t.Errorf("Distance = %v, want 25 km", info.Distance)
+	}
+	if want := b.Cycling.Calories() + b.Running.Calories(); info.Calories != want || want <= 0 {
+		t.Errorf("Calories = %v, want the sum of both legs %v", info.Calories, want)
+	}
+}
+
+func TestTrainingCaloriesMETFallback(t *testing.T) {
+	bare := testRun().Training
+	// 3 MET * 85 кг * 0.5 ч
+	if got := bare.Calories(); !almostEqual(got, 127.5, 1e-9) {
+		t.Errorf("Training.Calories() = %v, want 127.5", got)
+	}
+	if info := bare.TrainingInfo(); info.Calories <= 0 {
+		t.Errorf("Training.TrainingInfo().Calories = %v, want > 0", info.Calories)
+	}
+
+	bare.Weight = 0
+	if got := bare.Calories(); got != 0 {
+		t.Errorf("Training.Calories() without weight = %v, want 0", got)
+	}
+}
+
+func TestClampedMeanSpeedRunningSpike(t *testing.T) {
+	run := testRun() // 6.5 км/ч
+	if got := run.ClampedMeanSpeed(0); got != 6.5 {
+		t.Errorf("ClampedMeanSpeed(0) = %v, want 6.5 without a cap", got)
+	}
+	if got := run.ClampedMeanSpeed(6); got != 6 {
+		t.Errorf("ClampedMeanSpeed(6) = %v, want 6", got)
+	}
+
+	run.SpeedCap = 6
+	// (18 * 6 + 1.79) * 85 / 1000 * 0.5 * 60
+	if got := run.Calories(); !almostEqual(got, 279.9645, 1e-6) {
+		t.Errorf("Calories() with SpeedCap = %v, want 279.9645", got)
+	}
+	run.SpeedCap = 20
+	if got, want := run.Calories(), testRun().Calories(); got != want {
+		t.Errorf("Calories() with a cap above the speed = %v, want %v", got, want)
+	}
+}
+
+func TestClampedMeanSpeedSwimming(t *testing.T) {
+	swim := testSwim(40, 30*time.Minute) // 1 км по бассейну за 30 минут — 2 км/ч
+	swim.Action = 10                     // гребки скорость не определяют
+	if got := swim.ClampedMeanSpeed(0); got != 2 {
+		t.Errorf("ClampedMeanSpeed(0) = %v, want pool-based 2", got)
+	}
+	if got := swim.ClampedMeanSpeed(1.5); got != 1.5 {
+		t.Errorf("ClampedMeanSpeed(1.5) = %v, want 1.5", got)
+	}
+
+	swim.SpeedCap = 1.5
+	// (1.5 + 1.1) * 2 * 70 * 0.5
+	if got := swim.Calories(); !almostEqual(got, 182, 1e-9) {
+		t.Errorf("Calories() with SpeedCap = %v, want 182", got)
+	}
+}
+
+func TestRunTypeRecoveryVsTempo(t *testing.T) {
+	base := testRun().Calories()
+	recovery, tempo := testRun(), testRun()
+	recovery.RunType = RunRecovery
+	tempo.RunType = RunTempo
+
+	if got := recovery.Calories(); !almostEqual(got, base*0.9, 1e-9) {
+		t.Errorf("recovery Calories() = %v, want %v", got, base*0.9)
+	}
+	if got := tempo.Calories(); !almostEqual(got, base*1.05, 1e-9) {
+		t.Errorf("tempo Calories() = %v, want %v", got, base*1.05)
+	}
+	if recovery.Calories() >= tempo.Calories() {
+		t.Errorf("recovery %v kcal, want less than tempo %v kcal", recovery.Calories(), tempo.Calories())
+	}
+}
+
+func TestSwimmingDistanceMatchesPool(t *testing.T) {
+	s := Swimming{
+		Training: Training{
+			TrainingType: "Плавание",
+			Action:       700,
+			LenStep:      SwimmingLenStep,
+			Duration:     30 * time.Minute,
+			Weight:       70,
+		},
+		LengthPool: 50,
+		CountPool:  20,
+	}
+
+	info := s.TrainingInfo()
+	if info.Distance != 1.0 {
+		t.Errorf("Distance = %v, want 1.00 km", info.Distance)
+	}
+	if want := info.Distance / info.Duration.Hours(); !almostEqual(info.Speed, want, 1e-9) {
+		t.Errorf("Speed = %v, want Distance/Duration = %v", info.Speed, want)
+	}
+}
+
+func TestWalkingDistanceFromEstimatedStride(t *testing.T) {
+	if got := EstimateStepLength(180); !almostEqual(got, 0.747, 1e-9) {
+		t.Errorf("EstimateStepLength(180) = %v, want 0.747", got)
+	}
+	if got := EstimateStepLength(0); got != 0 {
+		t.Errorf("EstimateStepLength(0) = %v, want 0", got)
+	}
+
+	walk := testWalk(10000, 2*time.Hour)
+	walk.Height = 180
+	walk.LenStep = 0
+	info := walk.TrainingInfo()
+	if !almostEqual(info.Distance, 7.47, 1e-9) {
+		t.Errorf("Distance = %v, want 7.47 km from the estimated stride", info.Distance)
+	}
+	if !almostEqual(info.Speed, 3.735, 1e-9) {
+		t.Errorf("Speed = %v, want 3.735 km/h", info.Speed)
+	}
+
+	walk.LenStep = LenStep
+	if got := walk.TrainingInfo().Distance; !almostEqual(got, 6.5, 1e-9) {
+		t.Errorf("Distance with LenStep = %v, want 6.5 km", got)
+	}
+}
+
+func TestSwimmingLaps(t *testing.T) {
+	tests := []struct {
+		count, want int
+	}{
+		{5, 2},
+		{6, 3},
+		{1, 0},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		s := testSwim(tt.count, 10*time.Minute)
+		if got := s.Laps(); got != tt.want {
+			t.Errorf("Laps() for %d crossings = %d, want %d", tt.count, got, tt.want)
+		}
+		if got := s.TrainingInfo().Laps; got != tt.want {
+			t.Errorf("TrainingInfo().Laps for %d crossings = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestYouthCalories(t *testing.T) {
+	adult, child := testRun(), testRun()
+	adult.Age = 35
+	child.Age = 10
+
+	if got, want := adult.Calories(), testRun().Calories(); got != want {
+		t.Errorf("adult Calories() = %v, want %v without a youth factor", got, want)
+	}
+	if got, want := child.Calories(), adult.Calories()*YouthCaloriesFactor; !almostEqual(got, want, 1e-9) {
+		t.Errorf("10-year-old Calories() = %v, want %v", got, want)
+	}
+
+	teen := testRun()
+	teen.Age = YouthAge
+	if got, want := teen.Calories(), adult.Calories(); got != want {
+		t.Errorf("Calories() at age %d = %v, want the adult %v", YouthAge, got, want)
+	}
+}
+
+func TestRowing30Minutes(t *testing.T) {
+	row := Rowing{Training: Training{
+		TrainingType: "Гребля",
+		Action:       600,
+		LenStep:      RowingLenStep,
+		Duration:     30 * time.Minute,
+		Weight:       80,
+	}}
+	info := row.TrainingInfo()
+	if !almostEqual(info.Distance, 6, 1e-9) || !almostEqual(info.Speed, 12, 1e-9) {
+		t.Errorf("Distance, Speed = %v, %v, want 6 km, 12 km/h", info.Distance, info.Speed)
+	}
+	// (12 * 12 + 1.2) * 80 / 1000 * 0.5 * 60
+	if !almostEqual(info.Calories, 348.48, 1e-9) {
+		t.Errorf("Calories = %v, want 348.48", info.Calories)
+	}
+
+	want := "Тип тренировки: Гребля\nДлительность: 30 мин\nДистанция: 6.00 км.\nСр. скорость: 12.00 км/ч\nПотрачено ккал: 348.48\n"
+	if got := ReadData(row); got != want {
+		t.Errorf("ReadData() = %q, want %q", got, want)
+	}
+}
+
+func TestResistanceFactor(t *testing.T) {
+	base := testRun().Calories()
+	tests := []struct {
+		factor, want float64
+	}{
+		{1.5, base * 1.5},
+		{0, base},
+		{0.5, base},
+		{10, base * MaxResistanceFactor},
+	}
+	for _, tt := range tests {
+		run := testRun()
+		run.ResistanceFactor = tt.factor
+		if got := run.Calories(); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("Calories() with ResistanceFactor %v = %v, want %v", tt.factor, got, tt.want)
+		}
+	}
+
+	swim := testSwim(40, 30*time.Minute)
+	swim.ResistanceFactor = 1.5
+	if got, want := swim.Calories(), testSwim(40, 30*time.Minute).Calories()*1.5; !almostEqual(got, want, 1e-9) {
+		t.Errorf("swimming Calories() with ResistanceFactor 1.5 = %v, want %v", got, want)
+	}
+}
+
+func TestReadDataInfoCalories(t *testing.T) {
+	trainings := []CaloriesCalculator{
+		testRun(),
+		testWalk(6000, time.Hour),
+		testSwim(40, 30*time.Minute),
+		testRide(5000, time.Hour),
+		testBrick(),
+	}
+	for _, c := range trainings {
+		info := ReadDataInfo(c)
+		if info.Calories <= 0 || info.Calories != c.Calories() {
+			t.Errorf("%T: ReadDataInfo().Calories = %v, want Calories() = %v", c, info.Calories, c.Calories())
+		}
+		if got, want := ReadData(c), info.String(); got != want {
+			t.Errorf("%T: ReadData() = %q, want ReadDataInfo().String() %q", c, got, want)
+		}
+	}
+}
+
+func TestTrainingInfoCaloriesDirect(t *testing.T) {
+	runTraining := testRun()
+	if got := runTraining.TrainingInfo().Calories; got <= 0 || got != runTraining.Calories() {
+		t.Errorf("Running.TrainingInfo().Calories = %v, want Calories() = %v", got, runTraining.Calories())
+	}
+	walk := testWalk(6000, time.Hour)
+	if got := walk.TrainingInfo().Calories; got <= 0 || got != walk.Calories() {
+		t.Errorf("Walking.TrainingInfo().Calories = %v, want Calories() = %v", got, walk.Calories())
+	}
+}
+
+func TestIntervalWarmupAndTwoRuns(t *testing.T) {
+	warmup := testWalk(1000, 10*time.Minute)     // 0.65 км
+	run := NewRunFromPace(4, 12*time.Minute, 70) // 3 км
+	in := Interval{Segments: []CaloriesCalculator{warmup, run, run}}
+
+	info := in.TrainingInfo()
+	if info.TrainingType != IntervalTrainingType {
+		t.Errorf("TrainingType = %q, want %q", info.TrainingType, IntervalTrainingType)
+	}
+	if info.Duration != 34*time.Minute {
+		t.Errorf("Duration = %v, want 34m", info.Duration)
+	}
+	wantKm := warmup.Distance() + 2*run.Distance()
+	if !almostEqual(info.Distance, wantKm, 1e-9) {
+		t.Errorf("Distance = %v, want %v", info.Distance, wantKm)
+	}
+	if want := wantKm / info.Duration.Hours(); !almostEqual(info.Speed, want, 1e-9) {
+		t.Errorf("Speed = %v, want %v", info.Speed, want)
+	}
+	wantKcal := warmup.Calories() + 2*run.Calories()
+	if !almostEqual(in.Calories(), wantKcal, 1e-9) || !almostEqual(info.Calories, wantKcal, 1e-9) {
+		t.Errorf("Calories() = %v, info.Calories = %v, want %v", in.Calories(), info.Calories, wantKcal)
+	}
+}
+
+func TestExportedDistanceAndMeanSpeed(t *testing.T) {
+	stride := testWalk(10000, 2*time.Hour)
+	stride.LenStep = 0
+	tests := []struct {
+		name string
+		c    interface {
+			CaloriesCalculator
+			Distance() float64
+			MeanSpeed() float64
+		}
+	}{
+		{"running", testRun()},
+		{"walking", testWalk(6000, time.Hour)},
+		{"walking with estimated stride", stride},
+		{"swimming", testSwim(40, 30*time.Minute)},
+		{"training", testRun().Training},
+		{"recorded", Recorded{
+			Training:         Training{TrainingType: "Бег", Duration: time.Hour},
+			RecordedDistance: 10,
+			RecordedCalories: 600,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := tt.c.TrainingInfo()
+			if got := tt.c.Distance(); got != info.Distance || got <= 0 {
+				t.Errorf("Distance() = %v, want TrainingInfo().Distance %v", got, info.Distance)
+			}
+			if got := tt.c.MeanSpeed(); got != info.Speed || got <= 0 {
+				t.Errorf("MeanSpeed() = %v, want TrainingInfo().Speed %v", got, info.Speed)
+			}
+		})
+	}
+}
+
+func TestGenderCalories(t *testing.T) {
+	run := testRun()
+	walk := testWalk(4000, 40*time.Minute)
+	baseRun, baseWalk := run.Calories(), walk.Calories()
+
+	tests := []struct {
+		gender            Gender
+		runWant, walkWant float64
+	}{
+		{GenderUnset, baseRun, baseWalk},
+		{GenderMale, baseRun * 1.02, baseWalk * 1.01},
+		{GenderFemale, baseRun * 0.96, baseWalk * 0.97},
+	}
+	for _, tt := range tests {
+		run.Gender, walk.Gender = tt.gender, tt.gender
+		if got := run.Calories(); !almostEqual(got, tt.runWant, 1e-9) {
+			t.Errorf("gender %v: Running.Calories() = %v, want %v", tt.gender, got, tt.runWant)
+		}
+		if got := walk.Calories(); !almostEqual(got, tt.walkWant, 1e-9) {
+			t.Errorf("gender %v: Walking.Calories() = %v, want %v", tt.gender, got, tt.walkWant)
+		}
+	}
+}
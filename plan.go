@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Константы эталонных тренировок, из которых составляется план.
+const (
+	PlanRunningSpeed  = 9   // скорость бега в плане, км/ч
+	PlanWalkingSpeed  = 5   // скорость ходьбы в плане, км/ч
+	PlanSwimmingSpeed = 2   // скорость плавания в плане, км/ч
+	PlanPoolLength    = 25  // длина бассейна в плане, м
+	PlanHeight        = 175 // рост пользователя в плане, см
+)
+
+// planActivities задает порядок чередования тренировок в плане.
+var planActivities = []TrainingKind{KindRunning, KindWalking, KindSwimming}
+
+// planSessions строит эталонную тренировку каждого вида заданной длительности.
+var planSessions = map[TrainingKind]func(weight float64, d time.Duration) CaloriesCalculator{
+	KindRunning:  planRunning,
+	KindWalking:  planWalking,
+	KindSwimming: planSwimming,
+}
+
+// GeneratePlan возвращает план тренировок на неделю.
+// Недельная цель по калориям делится поровну между доступными днями (не больше 7),
+// виды тренировок чередуются: бег, ходьба, плавание. Каждая тренировка проходит
+// с постоянной эталонной скоростью, поэтому калории растут пропорционально времени,
+// и длительность дня подбирается как дневная_цель / калории_за_час_эталонной_тренировки.
+// Длительность округляется до минуты, поэтому сумма калорий плана примерно равна цели;
+// тренировка короче минуты не имеет смысла, и для маленькой цели каждая длится
+// минуту, а сумма калорий плана получается больше цели.
+func GeneratePlan(weeklyGoalKcal float64, availableDays int, weight float64) []CaloriesCalculator {
+	if weeklyGoalKcal <= 0 || availableDays <= 0 || weight <= 0 {
+		return nil
+	}
+	if availableDays > 7 {
+		availableDays = 7
+	}
+
+	dailyGoal := weeklyGoalKcal / float64(availableDays)
+	plan := make([]CaloriesCalculator, 0, availableDays)
+	for day := 0; day < availableDays; day++ {
+		build := planSessions[planActivities[day%len(planActivities)]]
+		perHour := build(weight, time.Hour).Calories()
+		if perHour <= 0 {
+			continue
+		}
+		d := time.Duration(dailyGoal / perHour * float64(time.Hour)).Round(time.Minute)
+		if d < time.Minute {
+			d = time.Minute
+		}
+		plan = append(plan, build(weight, d))
+	}
+	return plan
+}
+
+// BurnFood возвращает, сколько времени нужно тренироваться, чтобы потратить
+// килокалории продукта. Тренировка идет с эталонной скоростью вида из плана.
+// Для неизвестного вида тренировки возвращается 0.
+func BurnFood(foodKcal float64, kind TrainingKind, weight float64) time.Duration {
+	build, ok := planSessions[kind]
+	if !ok || foodKcal <= 0 || weight <= 0 {
+		return 0
+	}
+	perHour := build(weight, time.Hour).Calories()
+	if perHour <= 0 {
+		return 0
+	}
+	return time.Duration(foodKcal / perHour * float64(time.Hour)).Round(time.Second)
+}
+
+// CaloriesPerCurrency возвращает, сколько килокалорий тренировки c приходится
+// на единицу стоимости абонемента: стоимость одной тренировки — месячная плата
+// monthlyFee, деленная на количество тренировок за месяц sessionsThisMonth.
+// Без тренировок или платы возвращается 0.
+func CaloriesPerCurrency(monthlyFee float64, sessionsThisMonth int, c CaloriesCalculator) float64 {
+	if monthlyFee <= 0 || sessionsThisMonth <= 0 {
+		return 0
+	}
+	return c.Calories() / (monthlyFee / float64(sessionsThisMonth))
+}
+
+// StepGoalCalories возвращает, сколько килокалорий тратится на прогулку
+// в steps шагов длиной lenStep метров за время duration.
+// Рост пользователя берется из плана (PlanHeight).
+func StepGoalCalories(steps int, lenStep, weight float64, duration time.Duration) float64 {
+	walking := Walking{
+		Training: Training{
+			TrainingType: string(KindWalking),
+			Action:       steps,
+			LenStep:      lenStep,
+			Duration:     duration,
+			Weight:       weight,
+		},
+		Height: PlanHeight,
+	}
+	return walking.Calories()
+}
+
+// NegativeSplitPlan возвращает план пробежки на totalKm км по километровым отрезкам
+// с калориями каждого отрезка. Темп меняется линейно от startPace на первом километре
+// до endPace на последнем (мин/км): темп_i = startPace + (endPace - startPace) * i / (n - 1),
+// где n — количество отрезков. Последний отрезок может быть короче километра.
+func NegativeSplitPlan(totalKm, startPace, endPace, weight float64) []InfoMessage {
+	if totalKm <= 0 || startPace <= 0 || endPace <= 0 {
+		return nil
+	}
+
+	n := int(math.Ceil(totalKm))
+	splits := make([]InfoMessage, 0, n)
+	for i := 0; i < n; i++ {
+		pace := startPace
+		if n > 1 {
+			pace += (endPace - startPace) * float64(i) / float64(n-1)
+		}
+		km := math.Min(1, totalKm-float64(i))
+		d := time.Duration(pace * km * float64(time.Minute))
+		splits = append(splits, ReadDataInfo(NewRunFromPace(pace, d, weight)))
+	}
+	return splits
+}
+
+// planRunning возвращает пробежку с эталонной скоростью.
+func planRunning(weight float64, d time.Duration) CaloriesCalculator {
+	km := PlanRunningSpeed * d.Hours()
+	return Running{
+		Training: Training{
+			TrainingType: "Бег",
+			Action:       int(math.Round(km * MInKm / LenStep)),
+			LenStep:      LenStep,
+			Duration:     d,
+			Weight:       weight,
+		},
+	}
+}
+
+// planWalking возвращает прогулку с эталонной скоростью.
+func planWalking(weight float64, d time.Duration) CaloriesCalculator {
+	km := PlanWalkingSpeed * d.Hours()
+	return Walking{
+		Training: Training{
+			TrainingType: "Ходьба",
+			Action:       int(math.Round(km * MInKm / LenStep)),
+			LenStep:      LenStep,
+			Duration:     d,
+			Weight:       weight,
+		},
+		Height: PlanHeight,
+	}
+}
+
+// planSwimming возвращает заплыв с эталонной скоростью.
+func planSwimming(weight float64, d time.Duration) CaloriesCalculator {
+	km := PlanSwimmingSpeed * d.Hours()
+	return Swimming{
+		Training: Training{
+			TrainingType: "Плавание",
+			Action:       int(math.Round(km * MInKm / SwimmingLenStep)),
+			LenStep:      SwimmingLenStep,
+			Duration:     d,
+			Weight:       weight,
+		},
+		LengthPool: PlanPoolLength,
+		CountPool:  int(math.Round(km * MInKm / PlanPoolLength)),
+	}
+}
+
+// ErrInvalidGoal возвращается для неположительной цели по калориям.
+var ErrInvalidGoal = errors.New("некорректная цель по калориям")
+
+// GoalProgress возвращает прогресс к цели target килокалорий: сколько потрачено
+// на тренировках trainings, сколько осталось (не меньше 0) и процент выполнения,
+// ограниченный 100. Для неположительной цели возвращается ErrInvalidGoal.
+func GoalProgress(target float64, trainings []CaloriesCalculator) (burned float64, remaining float64, pct float64, err error) {
+	if target <= 0 {
+		return 0, 0, 0, fmt.Errorf("%w: %v", ErrInvalidGoal, target)
+	}
+	for _, t := range trainings {
+		burned += t.Calories()
+	}
+	remaining = math.Max(target-burned, 0)
+	pct = math.Min(burned/target*100, 100)
+	return burned, remaining, pct, nil
+}
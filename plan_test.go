@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// totalCalories возвращает сумму калорий тренировок.
+func totalCalories(trainings []CaloriesCalculator) float64 {
+	var total float64
+	for _, t := range trainings {
+		total += t.Calories()
+	}
+	return total
+}
+
+func TestGeneratePlanMatchesGoal(t *testing.T) {
+	const goal = 3000
+	plan := GeneratePlan(goal, 5, 75)
+	if len(plan) != 5 {
+		t.Fatalf("len(plan) = %d, want 5", len(plan))
+	}
+	if total := totalCalories(plan); !almostEqual(total, goal, goal*0.01) {
+		t.Errorf("total calories = %.2f, want ≈ %d", total, goal)
+	}
+
+	wantTypes := []string{"Бег", "Ходьба", "Плавание", "Бег", "Ходьба"}
+	for i, c := range plan {
+		if typ := c.TrainingInfo().TrainingType; typ != wantTypes[i] {
+			t.Errorf("plan[%d] type = %q, want %q", i, typ, wantTypes[i])
+		}
+	}
+}
+
+func TestGeneratePlanClampsDays(t *testing.T) {
+	if plan := GeneratePlan(7000, 10, 70); len(plan) != 7 {
+		t.Errorf("len(plan) = %d, want 7", len(plan))
+	}
+}
+
+func TestGeneratePlanSmallGoal(t *testing.T) {
+	plan := GeneratePlan(10, 7, 80)
+	if len(plan) != 7 {
+		t.Fatalf("len(plan) = %d, want 7", len(plan))
+	}
+	for i, c := range plan {
+		if d := c.TrainingInfo().Duration; d < time.Minute {
+			t.Errorf("plan[%d] duration = %v, want at least a minute", i, d)
+		}
+	}
+	if total := totalCalories(plan); total < 10 {
+		t.Errorf("total calories = %.2f, want at least the goal", total)
+	}
+}
+
+func TestGeneratePlanInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		goal   float64
+		days   int
+		weight float64
+	}{
+		{"zero goal", 0, 3, 70},
+		{"no days", 1000, 0, 70},
+		{"zero weight", 1000, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if plan := GeneratePlan(tt.goal, tt.days, tt.weight); plan != nil {
+				t.Errorf("GeneratePlan() = %v, want nil", plan)
+			}
+		})
+	}
+}
+
+func TestBurnFoodRunning(t *testing.T) {
+	d := BurnFood(250, KindRunning, 70)
+	// бег 9 км/ч весом 70 кг: (18 * 9 + 1.79) * 70 / 1000 * 60 ≈ 688 ккал/ч
+	if d < 21*time.Minute || d > 22*time.Minute+30*time.Second {
+		t.Errorf("BurnFood() = %v, want about 21m48s", d)
+	}
+	if got := planRunning(70, d).Calories(); !almostEqual(got, 250, 1) {
+		t.Errorf("run of %v burns %.2f kcal, want ≈ 250", d, got)
+	}
+}
+
+func TestBurnFoodUnknown(t *testing.T) {
+	if d := BurnFood(250, "Йога", 70); d != 0 {
+		t.Errorf("BurnFood(unknown kind) = %v, want 0", d)
+	}
+	if d := BurnFood(0, KindRunning, 70); d != 0 {
+		t.Errorf("BurnFood(0 kcal) = %v, want 0", d)
+	}
+}
+
+func TestStepGoalCalories(t *testing.T) {
+	// 10000 шагов по 0.65 м за час: 6.5 км/ч, вес 70 кг, рост 175 см.
+	got := StepGoalCalories(10000, LenStep, 70, time.Hour)
+	if want := 374.26; !almostEqual(got, want, 0.01) {
+		t.Errorf("StepGoalCalories(10000) = %.4f, want %.2f", got, want)
+	}
+	if StepGoalCalories(10000, LenStep, 0, time.Hour) != 0 {
+		t.Error("StepGoalCalories with zero weight is not 0")
+	}
+}
+
+func TestNegativeSplitPlan5K(t *testing.T) {
+	splits := NegativeSplitPlan(5, 6, 5, 70)
+	if len(splits) != 5 {
+		t.Fatalf("len = %d, want 5 splits", len(splits))
+	}
+
+	wantPaces := []float64{6, 5.75, 5.5, 5.25, 5}
+	for i, s := range splits {
+		if want := time.Duration(wantPaces[i] * float64(time.Minute)); s.Duration != want {
+			t.Errorf("split %d Duration = %v, want %v", i, s.Duration, want)
+		}
+		if !almostEqual(s.Distance, 1, 0.001) {
+			t.Errorf("split %d Distance = %v, want 1 km", i, s.Distance)
+		}
+		if s.Calories <= 0 {
+			t.Errorf("split %d Calories = %v, want > 0", i, s.Calories)
+		}
+		if i > 0 && s.Speed <= splits[i-1].Speed {
+			t.Errorf("split %d Speed = %v, want faster than %v", i, s.Speed, splits[i-1].Speed)
+		}
+	}
+}
+
+func TestNegativeSplitPlanPartialLastKm(t *testing.T) {
+	splits := NegativeSplitPlan(2.5, 6, 5, 70)
+	if len(splits) != 3 {
+		t.Fatalf("len = %d, want 3 splits", len(splits))
+	}
+	if last := splits[2]; !almostEqual(last.Distance, 0.5, 0.001) || last.Duration != 150*time.Second {
+		t.Errorf("last split = %.3f km in %v, want 0.5 km in 2m30s", last.Distance, last.Duration)
+	}
+	if NegativeSplitPlan(5, 0, 5, 70) != nil {
+		t.Error("NegativeSplitPlan() with zero pace is not nil")
+	}
+}
+
+func TestCaloriesPerCurrency(t *testing.T) {
+	run := testRun()
+	// 3000 за 10 тренировок — 300 за тренировку.
+	want := run.Calories() / 300
+	if got := CaloriesPerCurrency(3000, 10, run); !almostEqual(got, want, 1e-9) {
+		t.Errorf("CaloriesPerCurrency(3000, 10) = %v, want %v", got, want)
+	}
+	if got := CaloriesPerCurrency(3000, 0, run); got != 0 {
+		t.Errorf("CaloriesPerCurrency with no sessions = %v, want 0", got)
+	}
+	if got := CaloriesPerCurrency(0, 10, run); got != 0 {
+		t.Errorf("CaloriesPerCurrency with no fee = %v, want 0", got)
+	}
+}
+
+func TestGoalProgress(t *testing.T) {
+	trainings := []CaloriesCalculator{recordedKcal(300), recordedKcal(200)}
+
+	burned, remaining, pct, err := GoalProgress(1000, trainings)
+	if err != nil {
+		t.Fatalf("GoalProgress(1000) error = %v", err)
+	}
+	if burned != 500 || remaining != 500 || pct != 50 {
+		t.Errorf("GoalProgress(1000) = %v, %v, %v, want 500, 500, 50", burned, remaining, pct)
+	}
+
+	burned, remaining, pct, err = GoalProgress(400, trainings)
+	if err != nil {
+		t.Fatalf("GoalProgress(400) error = %v", err)
+	}
+	if burned != 500 || remaining != 0 || pct != 100 {
+		t.Errorf("GoalProgress(400) = %v, %v, %v, want 500, 0, 100", burned, remaining, pct)
+	}
+
+	if _, _, _, err := GoalProgress(0, trainings); !errors.Is(err, ErrInvalidGoal) {
+		t.Errorf("GoalProgress(0) error = %v, want ErrInvalidGoal", err)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+// Веса необязательных полей в оценке качества расчета калорий.
+// Без всех необязательных полей остается базовая уверенность 0.4.
+const (
+	QualityHeartRateWeight = 0.3  // вклад среднего пульса
+	QualityAgeWeight       = 0.15 // вклад возраста
+	QualityElevationWeight = 0.15 // вклад набора высоты
+)
+
+// EstimateQuality возвращает уверенность в оценке калорий от 0 до 1
+// и список необязательных полей, которых не хватает для более точного расчета.
+// Каждое отсутствующее поле уменьшает уверенность на свой вес.
+func EstimateQuality(c CaloriesCalculator) (score float64, missing []string) {
+	b, ok := c.(trainingBase)
+	if !ok {
+		return 0, []string{"AvgHeartRate", "Age", "ElevationGain"}
+	}
+	t := b.base()
+
+	score = 1
+	if t.AvgHeartRate <= 0 {
+		score -= QualityHeartRateWeight
+		missing = append(missing, "AvgHeartRate")
+	}
+	if t.Age <= 0 {
+		score -= QualityAgeWeight
+		missing = append(missing, "Age")
+	}
+	if t.ElevationGain <= 0 {
+		score -= QualityElevationWeight
+		missing = append(missing, "ElevationGain")
+	}
+	return score, missing
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEstimateQualityBareRun(t *testing.T) {
+	score, missing := EstimateQuality(testRun())
+	want := 1 - QualityHeartRateWeight - QualityAgeWeight - QualityElevationWeight
+	if !almostEqual(score, want, 1e-9) {
+		t.Errorf("score = %v, want %v", score, want)
+	}
+	if wantMissing := []string{"AvgHeartRate", "Age", "ElevationGain"}; !reflect.DeepEqual(missing, wantMissing) {
+		t.Errorf("missing = %v, want %v", missing, wantMissing)
+	}
+}
+
+func TestEstimateQualityFullRun(t *testing.T) {
+	run := testRun()
+	run.AvgHeartRate = 150
+	run.Age = 30
+	run.ElevationGain = 40
+
+	score, missing := EstimateQuality(run)
+	if score != 1 || len(missing) != 0 {
+		t.Errorf("EstimateQuality() = %v, %v, want 1, []", score, missing)
+	}
+}
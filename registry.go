@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Factory строит тренировку конкретного типа из общей части тренировки.
+type Factory func(Training) CaloriesCalculator
+
+// registry реестр типов тренировок по названию.
+var registry = struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}{
+	factories: map[string]Factory{
+		string(KindRunning): func(t Training) CaloriesCalculator {
+			return Running{Training: t}
+		},
+		string(KindWalking): func(t Training) CaloriesCalculator {
+			return Walking{Training: t, Height: PlanHeight}
+		},
+		string(KindSwimming): func(t Training) CaloriesCalculator {
+			return Swimming{
+				Training:   t,
+				LengthPool: DefaultPoolLength,
+				CountPool:  int(math.Round(t.distance() * MInKm / DefaultPoolLength)),
+			}
+		},
+		string(KindCycling): func(t Training) CaloriesCalculator {
+			return Cycling{Training: t}
+		},
+		string(KindRowing): func(t Training) CaloriesCalculator {
+			return Rowing{Training: t}
+		},
+	},
+}
+
+// RegisterTrainingType регистрирует тип тренировки name, который NewTraining
+// будет строить фабрикой f. Повторная регистрация заменяет прежнюю фабрику,
+// в том числе встроенную. Пробелы по краям названия не учитываются.
+// Функция безопасна для одновременного вызова из нескольких горутин.
+func RegisterTrainingType(name string, f Factory) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.factories[strings.TrimSpace(name)] = f
+}
+
+// NewTraining возвращает тренировку зарегистрированного типа name,
+// построенную из общей части base. Встроенные типы Бег, Ходьба, Плавание,
+// Велосипед и Гребля зарегистрированы заранее: для ходьбы рост берется
+// из плана (PlanHeight), для плавания бассейн длиной DefaultPoolLength,
+// а количество пересечений оценивается по дистанции. Для незарегистрированного
+// типа возвращается ошибка ErrUnknownTrainingType.
+func NewTraining(name string, base Training) (CaloriesCalculator, error) {
+	registry.mu.RLock()
+	f, ok := registry.factories[strings.TrimSpace(name)]
+	registry.mu.RUnlock()
+	if !ok || f == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTrainingType, name)
+	}
+	return f(base), nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// yoga пользовательский тип тренировки с 4 МЕТ.
+type yoga struct {
+	Training
+}
+
+func (y yoga) Calories() float64 {
+	return 4 * y.Weight * y.Duration.Hours()
+}
+
+func TestRegisterTrainingType(t *testing.T) {
+	RegisterTrainingType(" Йога ", func(base Training) CaloriesCalculator {
+		return yoga{Training: base}
+	})
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		delete(registry.factories, "Йога")
+	})
+
+	c, err := NewTraining("Йога", Training{TrainingType: "Йога", Duration: time.Hour, Weight: 60})
+	if err != nil {
+		t.Fatalf("NewTraining(Йога) error = %v", err)
+	}
+	if _, ok := c.(yoga); !ok {
+		t.Fatalf("NewTraining(Йога) = %T, want yoga", c)
+	}
+	if got := c.Calories(); got != 240 {
+		t.Errorf("Calories() = %v, want 240", got)
+	}
+}
+
+func TestNewTrainingBuiltin(t *testing.T) {
+	base := Training{Action: 5000, LenStep: LenStep, Duration: 30 * time.Minute, Weight: 85}
+	for name, want := range map[string]CaloriesCalculator{
+		"Бег":       Running{},
+		"Ходьба":    Walking{},
+		"Плавание":  Swimming{},
+		"Велосипед": Cycling{},
+		"Гребля":    Rowing{},
+	} {
+		c, err := NewTraining(name, base)
+		if err != nil {
+			t.Errorf("NewTraining(%s) error = %v", name, err)
+			continue
+		}
+		if kindOf(c) != kindOf(want) {
+			t.Errorf("NewTraining(%s) = %T, want %T", name, c, want)
+		}
+	}
+
+	if _, err := NewTraining("Кёрлинг", base); !errors.Is(err, ErrUnknownTrainingType) {
+		t.Errorf("NewTraining(Кёрлинг) error = %v, want ErrUnknownTrainingType", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import "time"
+
+// Sample замер во время тренировки.
+type Sample struct {
+	Elapsed  time.Duration // время от начала тренировки
+	Distance float64       // пройденная к этому моменту дистанция в км
+}
+
+// Downsample прореживает ряд замеров до не более чем maxPoints точек.
+// Точки выбираются с равным шагом по индексу, первая и последняя сохраняются всегда.
+// Если замеров и так не больше maxPoints, возвращается их копия.
+func Downsample(samples []Sample, maxPoints int) []Sample {
+	if maxPoints <= 0 || len(samples) == 0 {
+		return nil
+	}
+	if len(samples) <= maxPoints {
+		return append([]Sample(nil), samples...)
+	}
+	if maxPoints == 1 {
+		return []Sample{samples[0]}
+	}
+
+	last := len(samples) - 1
+	result := make([]Sample, 0, maxPoints)
+	for i := 0; i < maxPoints; i++ {
+		result = append(result, samples[i*last/(maxPoints-1)])
+	}
+	return result
+}
+
+// Константы для расчета пульсовых зон.
+const (
+	MaxHeartRateBase   = 220  // максимальный пульс = 220 - возраст
+	AnaerobicThreshold = 0.85 // порог анаэробного обмена в долях от максимального пульса
+)
+
+// HRSample замер пульса во время тренировки.
+type HRSample struct {
+	Elapsed time.Duration // время от начала тренировки
+	BPM     float64       // пульс, уд/мин
+}
+
+// AerobicAnaerobicSplit делит время тренировки на аэробное и анаэробное.
+// Пульс замера действует до следующего замера, последний замер только закрывает ряд.
+// Время с пульсом выше порога (85% от 220 - возраст) считается анаэробным.
+func AerobicAnaerobicSplit(samples []HRSample, age int) (aerobic, anaerobic time.Duration) {
+	if age <= 0 || age >= MaxHeartRateBase {
+		return 0, 0
+	}
+	threshold := AnaerobicThreshold * float64(MaxHeartRateBase-age)
+
+	for i := 0; i+1 < len(samples); i++ {
+		d := samples[i+1].Elapsed - samples[i].Elapsed
+		if d <= 0 {
+			continue
+		}
+		if samples[i].BPM > threshold {
+			anaerobic += d
+		} else {
+			aerobic += d
+		}
+	}
+	return aerobic, anaerobic
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDownsample(t *testing.T) {
+	samples := make([]Sample, 1000)
+	for i := range samples {
+		samples[i] = Sample{Elapsed: time.Duration(i) * time.Second, Distance: float64(i) / 100}
+	}
+
+	got := Downsample(samples, 100)
+	if len(got) != 100 {
+		t.Fatalf("len = %d, want 100", len(got))
+	}
+	if got[0] != samples[0] || got[len(got)-1] != samples[len(samples)-1] {
+		t.Errorf("first/last = %v/%v, want %v/%v", got[0], got[len(got)-1], samples[0], samples[len(samples)-1])
+	}
+	for i := 1; i < len(got); i++ {
+		step := got[i].Elapsed - got[i-1].Elapsed
+		if step < 10*time.Second || step > 11*time.Second {
+			t.Errorf("step %d = %v, want about 10s", i, step)
+		}
+	}
+}
+
+func TestDownsampleShort(t *testing.T) {
+	samples := []Sample{{0, 0}, {time.Minute, 0.2}}
+	got := Downsample(samples, 10)
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	got[0].Distance = 1
+	if samples[0].Distance != 0 {
+		t.Error("Downsample returned the input slice instead of a copy")
+	}
+}
+
+func TestAerobicAnaerobicSplit(t *testing.T) {
+	// порог для 30 лет: 0.85 * (220 - 30) = 161.5 уд/мин
+	samples := []HRSample{
+		{0, 120},
+		{10 * time.Minute, 170},
+		{15 * time.Minute, 150},
+		{30 * time.Minute, 140},
+	}
+	aerobic, anaerobic := AerobicAnaerobicSplit(samples, 30)
+	if aerobic != 25*time.Minute || anaerobic != 5*time.Minute {
+		t.Errorf("AerobicAnaerobicSplit() = %v, %v, want 25m, 5m", aerobic, anaerobic)
+	}
+}
+
+func TestAerobicAnaerobicSplitInvalidAge(t *testing.T) {
+	samples := []HRSample{{0, 120}, {time.Minute, 130}}
+	if aerobic, anaerobic := AerobicAnaerobicSplit(samples, 0); aerobic != 0 || anaerobic != 0 {
+		t.Errorf("AerobicAnaerobicSplit(age 0) = %v, %v, want 0, 0", aerobic, anaerobic)
+	}
+}
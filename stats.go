@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MixedTrainingType тип тренировки в сводных данных по тренировкам разных типов.
+const MixedTrainingType = "Смешанная"
+
+// Average возвращает среднее по двум тренировкам: длительность, дистанцию,
+// скорость и потраченные килокалории. Используется в командной статистике.
+// Если типы тренировок различаются, тип в результате — "Смешанная".
+func Average(a, b CaloriesCalculator) InfoMessage {
+	infoA, infoB := ReadDataInfo(a), ReadDataInfo(b)
+
+	trainingType := infoA.TrainingType
+	if infoA.TrainingType != infoB.TrainingType {
+		trainingType = MixedTrainingType
+	}
+
+	return InfoMessage{
+		TrainingType: trainingType,
+		Duration:     (infoA.Duration + infoB.Duration) / 2,
+		Distance:     (infoA.Distance + infoB.Distance) / 2,
+		Speed:        (infoA.Speed + infoB.Speed) / 2,
+		Calories:     (infoA.Calories + infoB.Calories) / 2,
+	}
+}
+
+// PaceDistribution распределяет время тренировки по зонам темпа в мин/км.
+// Границы зон zones задаются пользователем; зоны называются "<a", "a-b" и ">=b",
+// например для границ 5 и 6: "<5.00", "5.00-6.00" и ">=6.00".
+// Если тренировка разбита на отрезки Segments, время каждого отрезка попадает
+// в зону его темпа; отрезки без дистанции или времени пропускаются.
+// Без отрезков темп постоянный, и все время попадает в одну зону.
+// Без дистанции темп не определен и результат пустой.
+func PaceDistribution(c CaloriesCalculator, zones []float64) map[string]time.Duration {
+	result := make(map[string]time.Duration)
+	if b, ok := c.(trainingBase); ok && len(b.base().Segments) > 0 {
+		for _, seg := range b.base().Segments {
+			if seg.Duration > 0 && seg.Distance > 0 {
+				result[paceZone(seg.pace(), zones)] += seg.Duration
+			}
+		}
+		return result
+	}
+
+	info := c.TrainingInfo()
+	if info.Speed <= 0 || info.Duration <= 0 {
+		return result
+	}
+	result[paceZone(MinInHours/info.Speed, zones)] += info.Duration
+	return result
+}
+
+// paceZone возвращает название зоны, в которую попадает темп pace.
+func paceZone(pace float64, zones []float64) string {
+	bounds := append([]float64(nil), zones...)
+	sort.Float64s(bounds)
+
+	if len(bounds) == 0 {
+		return "все"
+	}
+	if pace < bounds[0] {
+		return fmt.Sprintf("<%.2f", bounds[0])
+	}
+	for i := 1; i < len(bounds); i++ {
+		if pace < bounds[i] {
+			return fmt.Sprintf("%.2f-%.2f", bounds[i-1], bounds[i])
+		}
+	}
+	return fmt.Sprintf(">=%.2f", bounds[len(bounds)-1])
+}
+
+// CarCO2PerKm средний выброс CO2 легковым автомобилем в граммах на км.
+const CarCO2PerKm = 170
+
+// CarbonSaved возвращает, сколько граммов CO2 не было выброшено, потому что
+// дистанция distanceKm пройдена пешком, бегом или на велосипеде, а не на машине.
+// Для остальных видов тренировок, которые не заменяют поездку, возвращается 0.
+func CarbonSaved(distanceKm float64, kind TrainingKind) float64 {
+	if distanceKm <= 0 {
+		return 0
+	}
+	switch kind {
+	case KindWalking, KindRunning, KindCycling:
+		return distanceKm * CarCO2PerKm
+	}
+	return 0
+}
+
+// RelativeEffort возвращает оценку нагрузки каждой тренировки от 0 до 100
+// относительно средней нагрузки по всем тренировкам.
+// Нагрузка — интенсивность (ккал/мин) × длительность в минутах, то есть потраченные
+// килокалории. Тренировка со средней нагрузкой получает 50, с вдвое большей и выше — 100.
+func RelativeEffort(trainings []CaloriesCalculator) []float64 {
+	if len(trainings) == 0 {
+		return nil
+	}
+
+	loads := make([]float64, len(trainings))
+	var total float64
+	for i, t := range trainings {
+		loads[i] = t.Calories()
+		total += loads[i]
+	}
+
+	scores := make([]float64, len(trainings))
+	mean := total / float64(len(trainings))
+	if mean <= 0 {
+		return scores
+	}
+	for i, load := range loads {
+		scores[i] = math.Min(50*load/mean, 100)
+	}
+	return scores
+}
+
+// WeightNormalizedComparison сравнивает тренировки пользователей с разным весом:
+// возвращает отношение килокалорий на килограмм веса тренировки a к килокалориям
+// на килограмм тренировки b. Значение больше 1 означает, что a тяжелее b.
+// Если вес одной из тренировок неизвестен или b не потратила калорий, возвращается 0.
+func WeightNormalizedComparison(a, b CaloriesCalculator) float64 {
+	perKgA, perKgB := caloriesPerKg(a), caloriesPerKg(b)
+	if perKgA <= 0 || perKgB <= 0 {
+		return 0
+	}
+	return perKgA / perKgB
+}
+
+// caloriesPerKg возвращает потраченные килокалории на килограмм веса пользователя
+// или 0, если вес неизвестен.
+func caloriesPerKg(c CaloriesCalculator) float64 {
+	b, ok := c.(trainingBase)
+	if !ok {
+		return 0
+	}
+	weight := b.base().weight()
+	if weight <= 0 {
+		return 0
+	}
+	return c.Calories() / weight
+}
+
+// DistanceHistogram возвращает количество тренировок в каждой корзине дистанции
+// шириной bucketKm км. Корзина с индексом n содержит дистанции от n*bucketKm
+// включительно до (n+1)*bucketKm; корзины без тренировок в результат не попадают.
+// При неположительной ширине корзины возвращается nil.
+func DistanceHistogram(trainings []CaloriesCalculator, bucketKm float64) map[int]int {
+	if bucketKm <= 0 {
+		return nil
+	}
+	histogram := make(map[int]int)
+	for _, t := range trainings {
+		distance := t.TrainingInfo().Distance
+		if distance < 0 {
+			continue
+		}
+		histogram[int(math.Floor(distance/bucketKm))]++
+	}
+	return histogram
+}
+
+// InterpolateCalories оценивает калории пропущенной тренировки между prev и next
+// линейной интерполяцией: frac = 0 дает калории prev, frac = 1 — калории next.
+// frac ограничивается диапазоном от 0 до 1.
+func InterpolateCalories(prev, next InfoMessage, frac float64) float64 {
+	frac = math.Max(0, math.Min(frac, 1))
+	return prev.Calories + (next.Calories-prev.Calories)*frac
+}
+
+// AveragePaceByType возвращает средний темп в мин/км по каждому виду тренировок.
+// Темп тренировки берется с весом, равным ее длительности:
+// сумма(темп_i * длительность_i) / сумма(длительность_i).
+// Тренировки без дистанции или длительности пропускаются.
+func AveragePaceByType(trainings []CaloriesCalculator) map[TrainingKind]float64 {
+	weighted := make(map[TrainingKind]float64)
+	minutes := make(map[TrainingKind]float64)
+	for _, t := range trainings {
+		info := t.TrainingInfo()
+		if info.Distance <= 0 || info.Duration <= 0 {
+			continue
+		}
+		kind := kindOf(t)
+		m := info.Duration.Minutes()
+		weighted[kind] += m / info.Distance * m
+		minutes[kind] += m
+	}
+
+	result := make(map[TrainingKind]float64, len(minutes))
+	for kind, m := range minutes {
+		result[kind] = weighted[kind] / m
+	}
+	return result
+}
+
+// MonotonyStrain возвращает монотонность и напряжение тренировочной недели по Фостеру.
+// Монотонность — средняя дневная нагрузка, деленная на ее стандартное отклонение,
+// напряжение — суммарная нагрузка за неделю, умноженная на монотонность.
+// При одинаковой нагрузке во все дни отклонение нулевое, монотонность
+// не определена и возвращается 0, 0.
+func MonotonyStrain(dailyLoads []float64) (monotony, strain float64) {
+	if len(dailyLoads) == 0 {
+		return 0, 0
+	}
+
+	var total float64
+	for _, load := range dailyLoads {
+		total += load
+	}
+	mean := total / float64(len(dailyLoads))
+
+	var squares float64
+	for _, load := range dailyLoads {
+		squares += (load - mean) * (load - mean)
+	}
+	sd := math.Sqrt(squares / float64(len(dailyLoads)))
+	if sd == 0 {
+		return 0, 0
+	}
+
+	monotony = mean / sd
+	return monotony, total * monotony
+}
+
+// PercentChange возвращает изменение тренировки new относительно old в процентах
+// по каждому числовому полю. Процент изменения длительности хранится в поле Duration
+// как количество минут, чтобы String() выводил его числом. Если в old поле
+// нулевое, изменение по нему не определено и равно 0. Тип тренировки берется из new.
+func PercentChange(old, new InfoMessage) InfoMessage {
+	return InfoMessage{
+		TrainingType: new.TrainingType,
+		Duration:     time.Duration(percentChange(old.Duration.Minutes(), new.Duration.Minutes()) * float64(time.Minute)),
+		Distance:     percentChange(old.Distance, new.Distance),
+		Speed:        percentChange(old.Speed, new.Speed),
+		Calories:     percentChange(old.Calories, new.Calories),
+	}
+}
+
+// percentChange возвращает изменение от old до new в процентах или 0 при нулевом old.
+func percentChange(old, new float64) float64 {
+	if old == 0 {
+		return 0
+	}
+	return (new - old) / math.Abs(old) * 100
+}
+
+// WeekSummary сводка тренировок за неделю.
+type WeekSummary struct {
+	TotalCalories float64                // сумма потраченных килокалорий
+	TotalDistance float64                // суммарная дистанция в км
+	TotalDuration time.Duration          // суммарная длительность
+	ByType        map[string]InfoMessage // сводка по каждому типу тренировки
+}
+
+// WeeklySummary возвращает сводку по тренировкам trainings: общие суммы
+// и суммы по каждому типу тренировки, включая пользовательские типы,
+// с ключом — названием типа. Скорость в сводке по типу — суммарная дистанция,
+// деленная на суммарную длительность.
+func WeeklySummary(trainings []CaloriesCalculator) WeekSummary {
+	summary := WeekSummary{ByType: make(map[string]InfoMessage)}
+	for _, t := range trainings {
+		info := ReadDataInfo(t)
+		summary.TotalCalories += info.Calories
+		summary.TotalDistance += info.Distance
+		summary.TotalDuration += info.Duration
+
+		agg := summary.ByType[info.TrainingType]
+		agg.TrainingType = info.TrainingType
+		agg.Calories += info.Calories
+		agg.Distance += info.Distance
+		agg.Duration += info.Duration
+		agg.Laps += info.Laps
+		if agg.Duration > 0 {
+			agg.Speed = agg.Distance / agg.Duration.Hours()
+		}
+		summary.ByType[info.TrainingType] = agg
+	}
+	return summary
+}
+
+// WeeklySummaries возвращает сводку WeeklySummary по каждой неделе, начинающейся
+// с дня weekStart, с ключом — началом недели из StartOfWeek.
+// Тренировки без StartTime пропускаются.
+func WeeklySummaries(trainings []CaloriesCalculator, weekStart time.Weekday) map[time.Time]WeekSummary {
+	weeks := GroupByWeek(trainings, weekStart)
+	summaries := make(map[time.Time]WeekSummary, len(weeks))
+	for week, ts := range weeks {
+		summaries[week] = WeeklySummary(ts)
+	}
+	return summaries
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAverageSameType(t *testing.T) {
+	a := testRun()
+	b := testRun()
+	b.Weight = 65
+	b.Duration = 40 * time.Minute
+
+	got := Average(a, b)
+	if got.TrainingType != "Бег" {
+		t.Errorf("TrainingType = %q, want Бег", got.TrainingType)
+	}
+	if got.Duration != 35*time.Minute {
+		t.Errorf("Duration = %v, want 35m", got.Duration)
+	}
+	if got.Distance != 3.25 {
+		t.Errorf("Distance = %v, want 3.25", got.Distance)
+	}
+	if want := (6.5 + 4.875) / 2; !almostEqual(got.Speed, want, 1e-9) {
+		t.Errorf("Speed = %v, want %v", got.Speed, want)
+	}
+	if want := (a.Calories() + b.Calories()) / 2; !almostEqual(got.Calories, want, 1e-9) {
+		t.Errorf("Calories = %v, want %v", got.Calories, want)
+	}
+}
+
+func TestAverageMixedTypes(t *testing.T) {
+	swim := testSwim(40, 30*time.Minute)
+	got := Average(testRun(), swim)
+	if got.TrainingType != MixedTrainingType {
+		t.Errorf("TrainingType = %q, want %q", got.TrainingType, MixedTrainingType)
+	}
+	if want := (3.25 + swim.TrainingInfo().Distance) / 2; !almostEqual(got.Distance, want, 1e-9) {
+		t.Errorf("Distance = %v, want %v", got.Distance, want)
+	}
+}
+
+func TestPaceDistributionConstantPace(t *testing.T) {
+	// 6.5 км/ч — темп около 9.23 мин/км
+	got := PaceDistribution(testRun(), []float64{6, 5, 10})
+	if len(got) != 1 || got["6.00-10.00"] != 30*time.Minute {
+		t.Errorf("PaceDistribution() = %v, want all 30m in 6.00-10.00", got)
+	}
+}
+
+func TestPaceDistributionNoDistance(t *testing.T) {
+	run := testRun()
+	run.Action = 0
+	if got := PaceDistribution(run, []float64{5, 6}); len(got) != 0 {
+		t.Errorf("PaceDistribution() = %v, want empty", got)
+	}
+}
+
+func TestPaceZone(t *testing.T) {
+	zones := []float64{5, 6}
+	tests := []struct {
+		pace float64
+		want string
+	}{
+		{4.5, "<5.00"},
+		{5, "5.00-6.00"},
+		{6, ">=6.00"},
+	}
+	for _, tt := range tests {
+		if got := paceZone(tt.pace, zones); got != tt.want {
+			t.Errorf("paceZone(%v) = %q, want %q", tt.pace, got, tt.want)
+		}
+	}
+}
+
+func TestCarbonSavedBikeRide(t *testing.T) {
+	if got, want := CarbonSaved(20, KindCycling), 20.0*CarCO2PerKm; got != want {
+		t.Errorf("CarbonSaved(20 km, cycling) = %v, want %v", got, want)
+	}
+	if got := CarbonSaved(2, KindSwimming); got != 0 {
+		t.Errorf("CarbonSaved(swimming) = %v, want 0", got)
+	}
+	if got := CarbonSaved(-5, KindCycling); got != 0 {
+		t.Errorf("CarbonSaved(-5 km) = %v, want 0", got)
+	}
+}
+
+// recordedKcal возвращает часовую тренировку с измеренными калориями kcal.
+func recordedKcal(kcal float64) Recorded {
+	return Recorded{
+		Training:         Training{TrainingType: string(KindRunning), Duration: time.Hour},
+		RecordedCalories: kcal,
+	}
+}
+
+func TestRelativeEffort(t *testing.T) {
+	trainings := []CaloriesCalculator{
+		recordedKcal(150), recordedKcal(300), recordedKcal(450), recordedKcal(1100),
+	}
+	// Средняя нагрузка 500 ккал: 50 баллов, вдвое больше и выше — 100.
+	want := []float64{15, 30, 45, 100}
+	got := RelativeEffort(trainings)
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !almostEqual(got[i], want[i], 1e-9) {
+			t.Errorf("RelativeEffort()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if RelativeEffort(nil) != nil {
+		t.Error("RelativeEffort(nil) is not nil")
+	}
+	for i, s := range RelativeEffort([]CaloriesCalculator{recordedKcal(0), recordedKcal(0)}) {
+		if s != 0 {
+			t.Errorf("zero load score[%d] = %v, want 0", i, s)
+		}
+	}
+}
+
+func TestPaceDistributionSegments(t *testing.T) {
+	run := testRun()
+	run.Segments = []Segment{
+		{Duration: 9 * time.Minute, Distance: 2},  // 4:30 /км
+		{Duration: 11 * time.Minute, Distance: 2}, // 5:30 /км
+		{Duration: 10 * time.Minute, Distance: 1}, // 10:00 /км
+		{Duration: 2 * time.Minute},               // остановка
+	}
+	got := PaceDistribution(run, []float64{5, 6})
+	want := map[string]time.Duration{
+		"<5.00":     9 * time.Minute,
+		"5.00-6.00": 11 * time.Minute,
+		">=6.00":    10 * time.Minute,
+	}
+	if len(got) != len(want) {
+		t.Errorf("PaceDistribution() = %v, want %v", got, want)
+	}
+	for zone, d := range want {
+		if got[zone] != d {
+			t.Errorf("PaceDistribution()[%q] = %v, want %v", zone, got[zone], d)
+		}
+	}
+}
+
+func TestInterpolateCalories(t *testing.T) {
+	prev, next := InfoMessage{Calories: 200}, InfoMessage{Calories: 400}
+	tests := []struct {
+		frac, want float64
+	}{
+		{0, 200},
+		{0.25, 250},
+		{1, 400},
+		{-1, 200},
+		{3, 400},
+	}
+	for _, tt := range tests {
+		if got := InterpolateCalories(prev, next, tt.frac); got != tt.want {
+			t.Errorf("InterpolateCalories(%v) = %v, want %v", tt.frac, got, tt.want)
+		}
+	}
+}
+
+func TestAveragePaceByType(t *testing.T) {
+	trainings := []CaloriesCalculator{
+		NewRunFromPace(5, 20*time.Minute, 70),
+		NewRunFromPace(6, 60*time.Minute, 70),
+		testWalk(6154, time.Hour), // ≈4 км
+	}
+	got := AveragePaceByType(trainings)
+	if len(got) != 2 {
+		t.Fatalf("AveragePaceByType() = %v, want running and walking", got)
+	}
+	// (5 * 20 + 6 * 60) / 80
+	if want := 5.75; !almostEqual(got[KindRunning], want, 0.01) {
+		t.Errorf("running pace = %v, want %v", got[KindRunning], want)
+	}
+	if want := 15.0; !almostEqual(got[KindWalking], want, 0.01) {
+		t.Errorf("walking pace = %v, want %v", got[KindWalking], want)
+	}
+}
+
+func TestMonotonyStrain(t *testing.T) {
+	// Неделя 2800 условных единиц: среднее 400, стандартное отклонение 92.58.
+	loads := []float64{300, 500, 300, 500, 300, 500, 400}
+	monotony, strain := MonotonyStrain(loads)
+	if !almostEqual(monotony, 4.3205, 0.0001) {
+		t.Errorf("monotony = %v, want 4.3205", monotony)
+	}
+	if !almostEqual(strain, 12097.38, 0.01) {
+		t.Errorf("strain = %v, want 12097.38", strain)
+	}
+
+	if m, s := MonotonyStrain([]float64{300, 300, 300}); m != 0 || s != 0 {
+		t.Errorf("MonotonyStrain(equal loads) = %v, %v, want 0, 0", m, s)
+	}
+	if m, s := MonotonyStrain(nil); m != 0 || s != 0 {
+		t.Errorf("MonotonyStrain(nil) = %v, %v, want 0, 0", m, s)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	old := InfoMessage{TrainingType: "Бег", Duration: 40 * time.Minute, Distance: 8, Speed: 12, Calories: 400}
+	new := InfoMessage{TrainingType: "Бег ", Duration: 30 * time.Minute, Distance: 10, Speed: 20, Calories: 500}
+
+	got := PercentChange(old, new)
+	want := InfoMessage{TrainingType: "Бег ", Duration: -25 * time.Minute, Distance: 25, Speed: 200.0 / 3, Calories: 25}
+	if got.TrainingType != want.TrainingType || got.Duration != want.Duration ||
+		!almostEqual(got.Distance, want.Distance, 1e-9) || !almostEqual(got.Speed, want.Speed, 1e-9) ||
+		!almostEqual(got.Calories, want.Calories, 1e-9) {
+		t.Errorf("PercentChange() = %+v, want %+v", got, want)
+	}
+
+	if got := PercentChange(InfoMessage{}, new); got.Duration != 0 || got.Distance != 0 || got.Speed != 0 || got.Calories != 0 {
+		t.Errorf("PercentChange() from zero = %+v, want zero changes", got)
+	}
+}
+
+func TestWeeklySummaryByType(t *testing.T) {
+	recorded := func(trainingType string, d time.Duration, km, kcal float64) Recorded {
+		return Recorded{
+			Training:         Training{TrainingType: trainingType, Duration: d},
+			RecordedDistance: km,
+			RecordedCalories: kcal,
+		}
+	}
+	trainings := []CaloriesCalculator{
+		recorded("Бег", 30*time.Minute, 5, 350),
+		recorded("Плавание", 45*time.Minute, 1.5, 300),
+		recorded("Бег", 60*time.Minute, 10, 650),
+		recorded("Йога", time.Hour, 0, 180),
+	}
+
+	s := WeeklySummary(trainings)
+	if s.TotalCalories != 1480 || s.TotalDistance != 16.5 || s.TotalDuration != 195*time.Minute {
+		t.Errorf("totals = %v kcal, %v km, %v, want 1480 kcal, 16.5 km, 3h15m",
+			s.TotalCalories, s.TotalDistance, s.TotalDuration)
+	}
+
+	want := map[string]InfoMessage{
+		"Бег":      {TrainingType: "Бег", Duration: 90 * time.Minute, Distance: 15, Speed: 10, Calories: 1000},
+		"Плавание": {TrainingType: "Плавание", Duration: 45 * time.Minute, Distance: 1.5, Speed: 2, Calories: 300},
+		"Йога":     {TrainingType: "Йога", Duration: time.Hour, Calories: 180},
+	}
+	if len(s.ByType) != len(want) {
+		t.Errorf("ByType = %v, want %d types", s.ByType, len(want))
+	}
+	for trainingType, w := range want {
+		got, ok := s.ByType[trainingType]
+		if !ok {
+			t.Errorf("ByType has no %q", trainingType)
+			continue
+		}
+		if got.TrainingType != w.TrainingType || got.Duration != w.Duration || got.Calories != w.Calories ||
+			!almostEqual(got.Distance, w.Distance, 1e-9) || !almostEqual(got.Speed, w.Speed, 1e-9) {
+			t.Errorf("ByType[%q] = %+v, want %+v", trainingType, got, w)
+		}
+	}
+}
+
+func TestWeightNormalizedComparison(t *testing.T) {
+	light := recordedKcal(600)
+	light.Weight = 60 // 10 ккал/кг
+	heavy := recordedKcal(800)
+	heavy.Weight = 100 // 8 ккал/кг
+
+	if got := WeightNormalizedComparison(light, heavy); !almostEqual(got, 1.25, 1e-9) {
+		t.Errorf("WeightNormalizedComparison(light, heavy) = %v, want 1.25", got)
+	}
+	if got := WeightNormalizedComparison(heavy, light); !almostEqual(got, 0.8, 1e-9) {
+		t.Errorf("WeightNormalizedComparison(heavy, light) = %v, want 0.8", got)
+	}
+
+	noWeight := recordedKcal(600)
+	if got := WeightNormalizedComparison(noWeight, heavy); got != 0 {
+		t.Errorf("WeightNormalizedComparison() without weight = %v, want 0", got)
+	}
+	if got := WeightNormalizedComparison(light, testBrick()); got != 0 {
+		t.Errorf("WeightNormalizedComparison() with a brick = %v, want 0", got)
+	}
+}
+
+func TestDistanceHistogram(t *testing.T) {
+	recorded := func(km float64) Recorded {
+		return Recorded{Training: Training{Duration: time.Hour}, RecordedDistance: km}
+	}
+	trainings := []CaloriesCalculator{
+		recorded(0), recorded(3), recorded(4.99), recorded(5), recorded(12), recorded(21.1),
+	}
+	got := DistanceHistogram(trainings, 5)
+	// Корзина i содержит дистанции от 5i включительно до 5(i+1) км.
+	want := map[int]int{0: 3, 1: 1, 2: 1, 4: 1}
+	if len(got) != len(want) {
+		t.Errorf("DistanceHistogram() = %v, want %v", got, want)
+	}
+	for bucket, n := range want {
+		if got[bucket] != n {
+			t.Errorf("bucket %d = %d, want %d", bucket, got[bucket], n)
+		}
+	}
+	if DistanceHistogram(trainings, 0) != nil {
+		t.Error("DistanceHistogram() with zero bucket is not nil")
+	}
+}
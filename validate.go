@@ -0,0 +1,326 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ошибки некорректных данных тренировки.
+var (
+	// ErrOutOfRange возвращается InRange для значения вне допустимого диапазона.
+	ErrOutOfRange = errors.New("значение вне диапазона")
+	// ErrInvalidWeight возвращается для веса пользователя вне диапазона.
+	ErrInvalidWeight = errors.New("некорректный вес")
+	// ErrZeroDuration возвращается для тренировки без продолжительности.
+	ErrZeroDuration = errors.New("нулевая продолжительность")
+	// ErrInvalidHeight возвращается для роста пользователя вне диапазона.
+	ErrInvalidHeight = errors.New("некорректный рост")
+	// ErrInvalidPool возвращается для бассейна без длины или с отрицательным
+	// количеством пересечений.
+	ErrInvalidPool = errors.New("некорректные размеры бассейна")
+	// ErrMissingLenStep возвращается TrainingInfoStrict, когда без длины шага
+	// дистанция тренировки получилась бы нулевой.
+	ErrMissingLenStep = errors.New("не задана длина шага")
+)
+
+// Допустимые диапазоны данных тренировки.
+const (
+	MinWeight     = 1   // минимальный вес пользователя в кг
+	MaxWeight     = 500 // максимальный вес пользователя в кг
+	MinHeight     = 30  // минимальный рост пользователя в см
+	MaxHeight     = 300 // максимальный рост пользователя в см
+	MaxPoolLength = 100 // максимальная длина бассейна в м
+	MaxPoolCount  = 1e6 // максимальное количество пересечений бассейна
+)
+
+// InRange возвращает ошибку ErrOutOfRange, если значение value поля field
+// не входит в диапазон [min, max]; обе границы включаются.
+func InRange(value, min, max float64, field string) error {
+	if value < min || value > max || math.IsNaN(value) {
+		return fmt.Errorf("%w: %s=%v, допустимо от %v до %v", ErrOutOfRange, field, value, min, max)
+	}
+	return nil
+}
+
+// validate проверяет общие данные тренировки, нужные для расчета калорий.
+func (t Training) validate() error {
+	if err := InRange(t.weight(), MinWeight, MaxWeight, "Weight"); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidWeight, err)
+	}
+	if t.Duration <= 0 {
+		return fmt.Errorf("%w: Duration=%v", ErrZeroDuration, t.Duration)
+	}
+	return nil
+}
+
+// validate проверяет данные ходьбы, нужные для расчета калорий.
+// Это переопределенный метод validate() из Training.
+func (w Walking) validate() error {
+	if err := w.Training.validate(); err != nil {
+		return err
+	}
+	if err := InRange(w.Height, MinHeight, MaxHeight, "Height"); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeight, err)
+	}
+	return nil
+}
+
+// validate проверяет данные плавания, нужные для расчета калорий.
+// Это переопределенный метод validate() из Training.
+func (s Swimming) validate() error {
+	if err := s.Training.validate(); err != nil {
+		return err
+	}
+	if err := InRange(float64(s.LengthPool), 1, MaxPoolLength, "LengthPool"); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPool, err)
+	}
+	if err := InRange(float64(s.CountPool), 0, MaxPoolCount, "CountPool"); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPool, err)
+	}
+	return nil
+}
+
+// Максимальные правдоподобные средние скорости в км/ч.
+const (
+	MaxRunningSpeed  = 45 // быстрее не бегают даже спринтеры
+	MaxWalkingSpeed  = 15 // рекорды спортивной ходьбы ниже
+	MaxSwimmingSpeed = 10 // рекорды на короткой воде ниже
+	MaxCyclingSpeed  = 80 // быстрее только за лидером или с горы
+	MaxRowingSpeed   = 25 // рекорды восьмерок ниже
+)
+
+// MaxSpeeds максимальные правдоподобные средние скорости по видам тренировок в км/ч,
+// которые используют Validate() и ConsistencyCheck(). Значения можно изменить;
+// для вида без значения или с неположительным значением скорость не проверяется.
+var MaxSpeeds = map[TrainingKind]float64{
+	KindRunning:  MaxRunningSpeed,
+	KindWalking:  MaxWalkingSpeed,
+	KindSwimming: MaxSwimmingSpeed,
+	KindCycling:  MaxCyclingSpeed,
+	KindRowing:   MaxRowingSpeed,
+}
+
+// Ошибки проверки правдоподобия тренировки.
+var (
+	// ErrImpossibleSpeed возвращается, когда длительность и дистанция
+	// тренировки дают физически невозможную скорость.
+	ErrImpossibleSpeed = errors.New("невозможная средняя скорость")
+	// ErrTooShort возвращается для тренировки короче MinDuration.
+	ErrTooShort = errors.New("слишком короткая тренировка")
+)
+
+// MinDuration возвращает минимальную длительность тренировки вида kind.
+// Границы взяты чуть ниже мировых рекордов на самых коротких дистанциях:
+//
+//	бег — 10 с (100 м);
+//	плавание — 20 с (50 м);
+//	велосипед — 10 с (спринт 200 м);
+//	ходьба — 1 мин;
+//	остальные виды — 10 с.
+func MinDuration(kind TrainingKind) time.Duration {
+	switch kind {
+	case KindSwimming:
+		return 20 * time.Second
+	case KindWalking:
+		return time.Minute
+	}
+	return 10 * time.Second
+}
+
+// checkSpeed проверяет, что средняя скорость тренировки вида kind не выше MaxSpeeds.
+func checkSpeed(kind TrainingKind, t Training, speed float64) error {
+	max, ok := MaxSpeeds[kind]
+	if !ok || max <= 0 {
+		return nil
+	}
+	if err := InRange(speed, 0, max, "Speed"); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrImpossibleSpeed, t.TrainingType, err)
+	}
+	return nil
+}
+
+// checkConsistency проверяет, что тренировка вида kind не короче MinDuration,
+// а ее средняя скорость не выше MaxSpeeds.
+func checkConsistency(kind TrainingKind, t Training, speed float64) error {
+	if minimum := MinDuration(kind); t.Duration < minimum {
+		return fmt.Errorf("%w: %s, %v при минимуме %v", ErrTooShort, t.TrainingType, t.Duration, minimum)
+	}
+	return checkSpeed(kind, t, speed)
+}
+
+// ConsistencyCheck возвращает ошибку, если скорость бега физически невозможна
+// или тренировка короче MinDuration.
+func (r Running) ConsistencyCheck() error {
+	return checkConsistency(KindRunning, r.Training, r.meanSpeed())
+}
+
+// ConsistencyCheck возвращает ошибку, если скорость ходьбы физически невозможна
+// или тренировка короче MinDuration.
+func (w Walking) ConsistencyCheck() error {
+	return checkConsistency(KindWalking, w.Training, w.meanSpeed())
+}
+
+// ConsistencyCheck возвращает ошибку, если скорость плавания физически невозможна
+// или тренировка короче MinDuration.
+func (s Swimming) ConsistencyCheck() error {
+	return checkConsistency(KindSwimming, s.Training, s.meanSpeed())
+}
+
+// Validate проверяет данные пробежки перед расчетом: возвращает ту же ошибку,
+// что CaloriesE(), или ErrImpossibleSpeed, если средняя скорость выше MaxSpeeds.
+// Calories() скорость не ограничивает.
+func (r Running) Validate() error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	return checkSpeed(KindRunning, r.Training, r.meanSpeed())
+}
+
+// Validate проверяет данные прогулки перед расчетом: возвращает ту же ошибку,
+// что CaloriesE(), или ErrImpossibleSpeed, если средняя скорость выше MaxSpeeds.
+func (w Walking) Validate() error {
+	if err := w.validate(); err != nil {
+		return err
+	}
+	return checkSpeed(KindWalking, w.Training, w.meanSpeed())
+}
+
+// Validate проверяет данные заплыва перед расчетом: возвращает ту же ошибку,
+// что CaloriesE(), или ErrImpossibleSpeed, если средняя скорость выше MaxSpeeds.
+func (s Swimming) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	return checkSpeed(KindSwimming, s.Training, s.meanSpeed())
+}
+
+// Validate проверяет данные поездки на велосипеде перед расчетом: возвращает ту же
+// ошибку, что CaloriesE(), или ErrImpossibleSpeed, если средняя скорость выше MaxSpeeds.
+func (c Cycling) Validate() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	return checkSpeed(KindCycling, c.Training, c.meanSpeed())
+}
+
+// Validate проверяет данные гребли перед расчетом: возвращает ту же ошибку,
+// что CaloriesE(), или ErrImpossibleSpeed, если средняя скорость выше MaxSpeeds.
+func (r Rowing) Validate() error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	return checkSpeed(KindRowing, r.Training, r.meanSpeed())
+}
+
+// ConsistencyCheck возвращает ошибку, если скорость езды на велосипеде физически
+// невозможна или тренировка короче MinDuration.
+func (c Cycling) ConsistencyCheck() error {
+	return checkConsistency(KindCycling, c.Training, c.meanSpeed())
+}
+
+// ConsistencyCheck возвращает ошибку, если скорость гребли физически невозможна
+// или тренировка короче MinDuration.
+func (r Rowing) ConsistencyCheck() error {
+	return checkConsistency(KindRowing, r.Training, r.meanSpeed())
+}
+
+// validator реализуют тренировки, которые умеют проверять свои данные перед расчетом.
+type validator interface {
+	Validate() error
+}
+
+// consistencyChecker реализуют тренировки, которые умеют проверять свою правдоподобность.
+type consistencyChecker interface {
+	ConsistencyCheck() error
+}
+
+// ValidateLog проверяет каждую тренировку журнала и возвращает ошибки только
+// по тренировкам, не прошедшим проверку, с ключом — индексом тренировки.
+// Для каждой тренировки выполняются Validate() и ConsistencyCheck(): проверяются
+// вес, длительность и параметры вида тренировки, скорость по MaxSpeeds
+// и минимальная длительность MinDuration. Тренировки без проверок считаются корректными.
+func ValidateLog(trainings []CaloriesCalculator) map[int]error {
+	errs := make(map[int]error)
+	for i, t := range trainings {
+		if v, ok := t.(validator); ok {
+			if err := v.Validate(); err != nil {
+				errs[i] = err
+				continue
+			}
+		}
+		if c, ok := t.(consistencyChecker); ok {
+			if err := c.ConsistencyCheck(); err != nil {
+				errs[i] = err
+			}
+		}
+	}
+	return errs
+}
+
+// checkLenStep возвращает ErrMissingLenStep, если длина шага тренировки не задана.
+func checkLenStep(t Training) error {
+	if t.LenStep <= 0 {
+		return fmt.Errorf("%w: %s, LenStep=%v", ErrMissingLenStep, t.TrainingType, t.LenStep)
+	}
+	return nil
+}
+
+// TrainingInfoStrict возвращает то же, что TrainingInfo(), или ErrMissingLenStep,
+// если без длины шага дистанция получилась бы нулевой.
+func (t Training) TrainingInfoStrict() (InfoMessage, error) {
+	if err := checkLenStep(t); err != nil {
+		return InfoMessage{}, err
+	}
+	return t.TrainingInfo(), nil
+}
+
+// TrainingInfoStrict возвращает то же, что TrainingInfo(), или ErrMissingLenStep,
+// если у пробежки не задана длина шага.
+// Это переопределенный метод TrainingInfoStrict() из Training.
+func (r Running) TrainingInfoStrict() (InfoMessage, error) {
+	if err := checkLenStep(r.Training); err != nil {
+		return InfoMessage{}, err
+	}
+	return r.TrainingInfo(), nil
+}
+
+// TrainingInfoStrict возвращает то же, что TrainingInfo(), или ErrMissingLenStep,
+// если у прогулки не задана длина шага и ее нельзя оценить по росту.
+// Это переопределенный метод TrainingInfoStrict() из Training.
+func (w Walking) TrainingInfoStrict() (InfoMessage, error) {
+	if w.Height <= 0 {
+		if err := checkLenStep(w.Training); err != nil {
+			return InfoMessage{}, err
+		}
+	}
+	return w.TrainingInfo(), nil
+}
+
+// TrainingInfoStrict возвращает то же, что TrainingInfo(): дистанция плавания
+// считается по бассейну, поэтому длина гребка не нужна и ошибки не бывает.
+// Это переопределенный метод TrainingInfoStrict() из Training.
+func (s Swimming) TrainingInfoStrict() (InfoMessage, error) {
+	return s.TrainingInfo(), nil
+}
+
+// TrainingInfoStrict возвращает то же, что TrainingInfo(), или ErrMissingLenStep,
+// если не задано расстояние за оборот педалей.
+// Это переопределенный метод TrainingInfoStrict() из Training.
+func (c Cycling) TrainingInfoStrict() (InfoMessage, error) {
+	if err := checkLenStep(c.Training); err != nil {
+		return InfoMessage{}, err
+	}
+	return c.TrainingInfo(), nil
+}
+
+// TrainingInfoStrict возвращает то же, что TrainingInfo(), или ErrMissingLenStep,
+// если не задан проход лодки за гребок.
+// Это переопределенный метод TrainingInfoStrict() из Training.
+func (r Rowing) TrainingInfoStrict() (InfoMessage, error) {
+	if err := checkLenStep(r.Training); err != nil {
+		return InfoMessage{}, err
+	}
+	return r.TrainingInfo(), nil
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSwim возвращает заплыв в бассейне 25 м с count пересечениями за время d.
+func testSwim(count int, d time.Duration) Swimming {
+	return Swimming{
+		Training: Training{
+			TrainingType: "Плавание",
+			Action:       count * 18,
+			LenStep:      SwimmingLenStep,
+			Duration:     d,
+			Weight:       70,
+		},
+		LengthPool: 25,
+		CountPool:  count,
+	}
+}
+
+// testRide возвращает поездку на велосипеде из revolutions оборотов педалей за время d.
+func testRide(revolutions int, d time.Duration) Cycling {
+	return Cycling{Training: Training{
+		TrainingType: "Велосипед",
+		Action:       revolutions,
+		LenStep:      CyclingLenStep,
+		Duration:     d,
+		Weight:       70,
+	}}
+}
+
+func TestConsistencyCheckSwimming(t *testing.T) {
+	// 1 км за 30 минут — 2 км/ч
+	if err := testSwim(40, 30*time.Minute).ConsistencyCheck(); err != nil {
+		t.Errorf("consistent swim: ConsistencyCheck() = %v, want nil", err)
+	}
+	// 5 км за 15 минут — 20 км/ч
+	err := testSwim(200, 15*time.Minute).ConsistencyCheck()
+	if !errors.Is(err, ErrImpossibleSpeed) {
+		t.Errorf("inconsistent swim: ConsistencyCheck() = %v, want ErrImpossibleSpeed", err)
+	}
+}
+
+func TestMinDuration(t *testing.T) {
+	tests := []struct {
+		kind TrainingKind
+		want time.Duration
+	}{
+		{KindRunning, 10 * time.Second},
+		{KindSwimming, 20 * time.Second},
+		{KindCycling, 10 * time.Second},
+		{KindWalking, time.Minute},
+		{KindRowing, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := MinDuration(tt.kind); got != tt.want {
+			t.Errorf("MinDuration(%q) = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestConsistencyCheckTooShort(t *testing.T) {
+	err := testSwim(1, 5*time.Second).ConsistencyCheck()
+	if !errors.Is(err, ErrTooShort) {
+		t.Errorf("5-second swim: ConsistencyCheck() = %v, want ErrTooShort", err)
+	}
+	if err := testSwim(1, 30*time.Second).ConsistencyCheck(); err != nil {
+		t.Errorf("30-second swim: ConsistencyCheck() = %v, want nil", err)
+	}
+}
+
+func TestValidateLog(t *testing.T) {
+	noWeight := testRun()
+	noWeight.Weight = 0
+	noHeight := testWalk(6000, time.Hour)
+	noHeight.Height = 0
+	row := Rowing{Training: Training{
+		TrainingType: "Гребля",
+		Action:       1000,
+		LenStep:      RowingLenStep,
+		Duration:     time.Hour,
+		Weight:       80,
+	}}
+	fastRow := row
+	fastRow.Action = 5000
+
+	trainings := []CaloriesCalculator{
+		testRun(),
+		noWeight,
+		testWalk(6000, time.Hour),
+		noHeight,
+		testSwim(40, 30*time.Minute),
+		testRide(5000, time.Hour),
+		testRide(200000, time.Hour), // 1100 км/ч
+		testRide(3, 5*time.Second),
+		row,
+		fastRow, // 50 км/ч
+	}
+	want := map[int]error{
+		1: ErrInvalidWeight,
+		3: ErrInvalidHeight,
+		6: ErrImpossibleSpeed,
+		7: ErrTooShort,
+		9: ErrImpossibleSpeed,
+	}
+
+	errs := ValidateLog(trainings)
+	if len(errs) != len(want) {
+		t.Errorf("ValidateLog() = %v, want errors at %d indices", errs, len(want))
+	}
+	for i, target := range want {
+		if !errors.Is(errs[i], target) {
+			t.Errorf("ValidateLog()[%d] = %v, want %v", i, errs[i], target)
+		}
+	}
+}
+
+// caloriesE реализуют тренировки, которые возвращают ошибку расчета калорий.
+type caloriesE interface {
+	CaloriesCalculator
+	CaloriesE() (float64, error)
+}
+
+func TestCaloriesESentinels(t *testing.T) {
+	noWeight := testRun()
+	noWeight.Weight = 0
+	noDuration := testRun()
+	noDuration.Duration = 0
+	heavyWalk := testWalk(6000, time.Hour)
+	heavyWalk.Weight = 600
+	noHeight := testWalk(6000, time.Hour)
+	noHeight.Height = 0
+	noPool := testSwim(40, 30*time.Minute)
+	noPool.LengthPool = 0
+	negativeCount := testSwim(-1, 30*time.Minute)
+
+	tests := []struct {
+		name  string
+		c     caloriesE
+		want  error
+		field string
+	}{
+		{"running zero weight", noWeight, ErrInvalidWeight, "Weight=0"},
+		{"running zero duration", noDuration, ErrZeroDuration, "Duration=0s"},
+		{"walking too heavy", heavyWalk, ErrInvalidWeight, "Weight=600"},
+		{"walking zero height", noHeight, ErrInvalidHeight, "Height=0"},
+		{"swimming zero pool", noPool, ErrInvalidPool, "LengthPool=0"},
+		{"swimming negative count", negativeCount, ErrInvalidPool, "CountPool=-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calories, err := tt.c.CaloriesE()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("CaloriesE() error = %v, want %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("error %q does not mention %s", err, tt.field)
+			}
+			if calories != 0 || tt.c.Calories() != 0 {
+				t.Errorf("calories = %v, Calories() = %v, want 0", calories, tt.c.Calories())
+			}
+		})
+	}
+}
+
+func TestCaloriesEValid(t *testing.T) {
+	recorded := Recorded{Training: Training{TrainingType: "Бег", Duration: time.Hour, Weight: 70}, RecordedDistance: 10, RecordedCalories: 600}
+	for _, c := range []caloriesE{testRun(), testWalk(6000, time.Hour), testSwim(40, 30*time.Minute), recorded} {
+		calories, err := c.CaloriesE()
+		if err != nil {
+			t.Errorf("%T.CaloriesE() error = %v, want nil", c, err)
+		}
+		if calories <= 0 || calories != c.Calories() {
+			t.Errorf("%T.CaloriesE() = %v, want Calories() = %v", c, calories, c.Calories())
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		ok    bool
+	}{
+		{"min included", 1, true},
+		{"inside", 70, true},
+		{"max included", 500, true},
+		{"below", 0.5, false},
+		{"above", 501, false},
+		{"NaN", math.NaN(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := InRange(tt.value, MinWeight, MaxWeight, "Weight")
+			if tt.ok {
+				if err != nil {
+					t.Errorf("InRange(%v) = %v, want nil", tt.value, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrOutOfRange) {
+				t.Fatalf("InRange(%v) = %v, want ErrOutOfRange", tt.value, err)
+			}
+			if !strings.Contains(err.Error(), "Weight=") || !strings.Contains(err.Error(), "от 1 до 500") {
+				t.Errorf("InRange(%v) message %q does not name the field and range", tt.value, err)
+			}
+		})
+	}
+}
+
+func TestValidateMarathonDuration(t *testing.T) {
+	marathon := testRun()
+	marathon.Action = 64915 // 42.195 км
+
+	marathon.Duration = 3*time.Hour + 30*time.Minute
+	if err := marathon.Validate(); err != nil {
+		t.Errorf("3h30m marathon: Validate() = %v, want nil", err)
+	}
+
+	marathon.Duration = 5 * time.Minute
+	err := marathon.Validate()
+	if !errors.Is(err, ErrImpossibleSpeed) {
+		t.Fatalf("5-minute marathon: Validate() = %v, want ErrImpossibleSpeed", err)
+	}
+	if !strings.Contains(err.Error(), "Speed=") {
+		t.Errorf("error %q does not report the speed", err)
+	}
+	if marathon.Calories() <= 0 {
+		t.Error("Validate() must not change Calories()")
+	}
+}
+
+func TestValidateConfigurableMaxSpeed(t *testing.T) {
+	saved := MaxSpeeds[KindRunning]
+	t.Cleanup(func() { MaxSpeeds[KindRunning] = saved })
+
+	MaxSpeeds[KindRunning] = 6 // testRun бежит 6.5 км/ч
+	if err := testRun().Validate(); !errors.Is(err, ErrImpossibleSpeed) {
+		t.Errorf("Validate() with a 6 km/h cap = %v, want ErrImpossibleSpeed", err)
+	}
+	MaxSpeeds[KindRunning] = 0
+	if err := testRun().Validate(); err != nil {
+		t.Errorf("Validate() without a cap = %v, want nil", err)
+	}
+}
+
+func TestTrainingInfoStrict(t *testing.T) {
+	run := testRun()
+	run.LenStep = 0
+
+	if _, err := run.TrainingInfoStrict(); !errors.Is(err, ErrMissingLenStep) {
+		t.Errorf("Running.TrainingInfoStrict() with LenStep=0 error = %v, want ErrMissingLenStep", err)
+	}
+	if info := run.TrainingInfo(); info.Distance != 0 {
+		t.Errorf("non-strict TrainingInfo().Distance = %v, want 0", info.Distance)
+	}
+
+	ride := testRide(3636, 40*time.Minute)
+	ride.LenStep = 0
+	if _, err := ride.TrainingInfoStrict(); !errors.Is(err, ErrMissingLenStep) {
+		t.Errorf("Cycling.TrainingInfoStrict() with LenStep=0 error = %v, want ErrMissingLenStep", err)
+	}
+
+	swim := testSwim(40, 30*time.Minute)
+	swim.LenStep = 0
+	if _, err := swim.TrainingInfoStrict(); err != nil {
+		t.Errorf("Swimming.TrainingInfoStrict() error = %v, want nil", err)
+	}
+
+	info, err := testRun().TrainingInfoStrict()
+	if err != nil {
+		t.Fatalf("TrainingInfoStrict() error = %v", err)
+	}
+	if want := testRun().TrainingInfo(); info.Distance != want.Distance || info.Calories != want.Calories {
+		t.Errorf("TrainingInfoStrict() = %+v, want %+v", info, want)
+	}
+}